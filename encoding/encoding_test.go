@@ -32,6 +32,25 @@ func TestByteLinear(t *testing.T) {
 	}
 }
 
+func TestBlockAffineRoundTrip(t *testing.T) {
+	a := matrix.GenerateRandomAffine(rand.Reader, 128)
+	ba := NewBlockAffineFrom(a)
+
+	if !ba.Affine().Equals(a) {
+		t.Fatal("Affine map changed after round trip through BlockAffine.")
+	}
+
+	in := [16]byte{}
+	rand.Read(in[:])
+	out := ba.Encode(in)
+
+	if !a.Apply(matrix.Row(in[:])).Equals(matrix.Row(out[:])) {
+		t.Fatal("BlockAffine and affine map disagree.")
+	} else if ba.Decode(out) != in {
+		t.Fatal("BlockAffine didn't Encode/Decode correctly.")
+	}
+}
+
 func BenchmarkGenerateSBox(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		GenerateSBox(rand.Reader)
@@ -58,6 +58,23 @@ func NewByteAffine(forwards matrix.Matrix, constant byte) ByteAffine {
 func (ba ByteAffine) Encode(in byte) byte { return ba.ByteAdditive.Encode(ba.ByteLinear.Encode(in)) }
 func (ba ByteAffine) Decode(in byte) byte { return ba.ByteLinear.Decode(ba.ByteAdditive.Decode(in)) }
 
+// NewByteAffineFrom constructs a new ByteAffine encoding from an affine map on GF(2)^8.
+func NewByteAffineFrom(a matrix.Affine) ByteAffine {
+	if r, c := a.Size(); r != 8 || c != 8 || a.Constant.Size() != 8 {
+		panic("Wrong size affine map given to NewByteAffineFrom!")
+	}
+
+	return NewByteAffine(a.Linear, a.Constant[0])
+}
+
+// Affine returns the affine map underlying the encoding.
+func (ba ByteAffine) Affine() matrix.Affine {
+	return matrix.Affine{
+		Linear:   ba.Forwards.Dup(),
+		Constant: matrix.Row{byte(ba.ByteAdditive)},
+	}
+}
+
 // DoubleAdditive implements the Double interface over XORing with a fixed value.
 type DoubleAdditive [2]byte
 
@@ -122,6 +139,26 @@ func (da DoubleAffine) Decode(in [2]byte) [2]byte {
 	return da.DoubleLinear.Decode(da.DoubleAdditive.Decode(in))
 }
 
+// NewDoubleAffineFrom constructs a new DoubleAffine encoding from an affine map on GF(2)^16.
+func NewDoubleAffineFrom(a matrix.Affine) DoubleAffine {
+	if r, c := a.Size(); r != 16 || c != 16 || a.Constant.Size() != 16 {
+		panic("Wrong size affine map given to NewDoubleAffineFrom!")
+	}
+
+	constant := [2]byte{}
+	copy(constant[:], a.Constant)
+
+	return NewDoubleAffine(a.Linear, constant)
+}
+
+// Affine returns the affine map underlying the encoding.
+func (da DoubleAffine) Affine() matrix.Affine {
+	return matrix.Affine{
+		Linear:   da.Forwards.Dup(),
+		Constant: matrix.Row(da.DoubleAdditive[:]).Dup(),
+	}
+}
+
 // WordAdditive implements the Word interface over XORing with a fixed value.
 type WordAdditive [4]byte
 
@@ -186,6 +223,26 @@ func (wa WordAffine) Decode(in [4]byte) [4]byte {
 	return wa.WordLinear.Decode(wa.WordAdditive.Decode(in))
 }
 
+// NewWordAffineFrom constructs a new WordAffine encoding from an affine map on GF(2)^32.
+func NewWordAffineFrom(a matrix.Affine) WordAffine {
+	if r, c := a.Size(); r != 32 || c != 32 || a.Constant.Size() != 32 {
+		panic("Wrong size affine map given to NewWordAffineFrom!")
+	}
+
+	constant := [4]byte{}
+	copy(constant[:], a.Constant)
+
+	return NewWordAffine(a.Linear, constant)
+}
+
+// Affine returns the affine map underlying the encoding.
+func (wa WordAffine) Affine() matrix.Affine {
+	return matrix.Affine{
+		Linear:   wa.Forwards.Dup(),
+		Constant: matrix.Row(wa.WordAdditive[:]).Dup(),
+	}
+}
+
 // BlockAdditive implements the Block interface over XORing with a fixed value.
 type BlockAdditive [16]byte
 
@@ -249,3 +306,23 @@ func (ba BlockAffine) Encode(in [16]byte) [16]byte {
 func (ba BlockAffine) Decode(in [16]byte) [16]byte {
 	return ba.BlockLinear.Decode(ba.BlockAdditive.Decode(in))
 }
+
+// NewBlockAffineFrom constructs a new BlockAffine encoding from an affine map on GF(2)^128.
+func NewBlockAffineFrom(a matrix.Affine) BlockAffine {
+	if r, c := a.Size(); r != 128 || c != 128 || a.Constant.Size() != 128 {
+		panic("Wrong size affine map given to NewBlockAffineFrom!")
+	}
+
+	constant := [16]byte{}
+	copy(constant[:], a.Constant)
+
+	return NewBlockAffine(a.Linear, constant)
+}
+
+// Affine returns the affine map underlying the encoding.
+func (ba BlockAffine) Affine() matrix.Affine {
+	return matrix.Affine{
+		Linear:   ba.Forwards.Dup(),
+		Constant: matrix.Row(ba.BlockAdditive[:]).Dup(),
+	}
+}
@@ -1,8 +1,6 @@
 package equivalence
 
 import (
-	"fmt"
-
 	"github.com/OpenWhiteBox/primitives/encoding"
 	"github.com/OpenWhiteBox/primitives/matrix"
 )
@@ -20,23 +18,26 @@ func search(f, g encoding.Byte, A, B *matrix.DeductiveMatrix, posA, posB, cap in
 	// 1. Take a guess for A(x).
 	// 2. Check if its possible for any matrix B to satisfy an equivalence relation with what we know about A.
 	for i := 0; i < novelOutputSize; i++ {
-		AT, BT := A.Dup(), B.Dup()
-		AT.Assert(x, A.Output.NovelRow(i))
+		// Guess in place and roll back afterwards, rather than duplicating A and B for every guess.
+		markA, markB := A.Checkpoint(), B.Checkpoint()
+		A.Assert(x, A.Output.NovelRow(i))
 
-		posAT, posBT, consistent := learn(f, g, AT, BT, posA, posB)
+		posAT, posBT, consistent := learn(f, g, A, B, posA, posB)
 
 		// Our guess for A(x) ...
 		if !consistent { // ... isn't consistent with any equivalence relation.
-			continue
-		} else if AT.FullyDefined() { // ... uniquely specified an equivalence relation.
+		} else if A.FullyDefined() { // ... uniquely specified an equivalence relation.
 			res = append(res, Linear{
-				A: encoding.NewByteLinear(AT.Matrix()),
-				B: encoding.NewByteLinear(BT.Matrix()),
+				A: encoding.NewByteLinear(A.Matrix()),
+				B: encoding.NewByteLinear(B.Matrix()),
 			})
 		} else { // ... has neither led to a contradiction nor a full definition.
-			res = append(res, search(f, g, AT, BT, posAT, posBT, cap-len(res))...)
+			res = append(res, search(f, g, A, B, posAT, posBT, cap-len(res))...)
 		}
 
+		A.Rollback(markA)
+		B.Rollback(markB)
+
 		if len(res) >= cap {
 			return
 		}
@@ -50,17 +51,6 @@ func search(f, g encoding.Byte, A, B *matrix.DeductiveMatrix, posA, posB, cap in
 // learn returns whether or not A and B are consistent with any possible equivalence. A and B are mutated to contain the
 // new information.
 func learn(f, g encoding.Byte, A, B *matrix.DeductiveMatrix, posA, posB int) (posAT, posBT int, consistent bool) {
-	defer func() {
-		if r := recover(); r != nil {
-			if fmt.Sprint(r) == "Asserted input, output pair is inconsistent with previous assertions!" {
-				consistent = false
-			} else {
-				panic(r)
-			}
-		}
-	}()
-
-	consistent = true
 	learning := true
 
 	size := 0
@@ -80,7 +70,12 @@ func learn(f, g encoding.Byte, A, B *matrix.DeductiveMatrix, posA, posB int) (po
 			xT := matrix.Row{g.Encode(x[0])}
 			yT := matrix.Row{f.Encode(y[0])}
 
-			learned := B.Assert(xT, yT)
+			learned, err := B.CheckedAssert(xT, yT)
+			if err == matrix.ErrInconsistentAssertion {
+				return posA, posB, false
+			} else if err != nil {
+				panic(err)
+			}
 			learning = learning || learned
 		}
 
@@ -95,10 +90,15 @@ func learn(f, g encoding.Byte, A, B *matrix.DeductiveMatrix, posA, posB int) (po
 			z := matrix.Row{g.Decode(x[0])}
 			Az := matrix.Row{f.Decode(y[0])}
 
-			learned := A.Assert(z, Az)
+			learned, err := A.CheckedAssert(z, Az)
+			if err == matrix.ErrInconsistentAssertion {
+				return posA, posB, false
+			} else if err != nil {
+				panic(err)
+			}
 			learning = learning || learned
 		}
 	}
 
-	return posA, posB, consistent
+	return posA, posB, true
 }
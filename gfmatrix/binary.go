@@ -0,0 +1,250 @@
+package gfmatrix
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/OpenWhiteBox/primitives/matrix"
+)
+
+// Binary returns the row as a binary row, where entry i is written in the polynomial basis to bits k*i through
+// k*i + k-1 and the field is GF(2^k). Over GF(2^8), entry i is byte i.
+func (e RowOf[E]) Binary() matrix.Row {
+	k := fieldDegree[E]()
+	out := matrix.NewRow(k * e.Size())
+
+	for i, e_i := range e {
+		for b := 0; b < k; b++ {
+			out.SetBit(k*i+b, e_i>>uint(b)&1 == 1)
+		}
+	}
+
+	return out
+}
+
+// Binary returns the binary matrix of the same linear map, acting on the binary form of rows given by RowOf.Binary:
+// each entry is expanded into the k-by-k binary matrix of multiplication by it, so that
+// e.Binary().Mul(x.Binary()) = e.Mul(x).Binary().
+func (e MatrixOf[E]) Binary() matrix.Matrix {
+	n, m := e.Size()
+	k := fieldDegree[E]()
+
+	out := matrix.GenerateEmpty(n*k, m*k)
+
+	for i, row := range e {
+		for j, elem := range row {
+			if elem.IsZero() {
+				continue
+			}
+
+			for c := 0; c < k; c++ {
+				col := elem.Mul(E(1) << uint(c))
+
+				for r := 0; r < k; r++ {
+					out[i*k+r].SetBit(j*k+c, col>>uint(r)&1 == 1)
+				}
+			}
+		}
+	}
+
+	return out
+}
+
+// FromBinary returns the matrix over E whose binary matrix is m, if there is one. That is, it returns false unless m is
+// E-linear when its input and output are split into field elements written in the polynomial basis. Rows of m are
+// padded to a whole number of bytes, so for fields smaller than GF(2^8), padding bits are read as extra zero columns.
+func FromBinary[E Element[E]](m matrix.Matrix) (MatrixOf[E], bool) {
+	n, p := m.Size()
+	k := fieldDegree[E]()
+
+	if n%k != 0 {
+		return nil, false
+	}
+
+	out := GenerateEmptyOf[E](n/k, p/k)
+
+	for i, row := range out {
+		for j, _ := range row {
+			// The first column of the block is the entry times one.
+			elem := E(0)
+			for r := 0; r < k; r++ {
+				elem |= E(m[i*k+r].GetBit(j*k)) << uint(r)
+			}
+
+			// The rest have to match the entry times each power of x.
+			for c := 1; c < k; c++ {
+				col := elem.Mul(E(1) << uint(c))
+
+				for r := 0; r < k; r++ {
+					if m[i*k+r].GetBit(j*k+c) != byte(col>>uint(r)&1) {
+						return nil, false
+					}
+				}
+			}
+
+			row[j] = elem
+		}
+	}
+
+	return out, true
+}
+
+// FromBinaryBasis is FromBinary, but for a binary matrix whose input and output elements are written in some other
+// basis of the field. Column i of the k-by-k binary matrix basis is how x^i is written. For example, a layer
+// that's been obfuscated with the same linear encoding on every byte of its input and output is linear over GF(2^8)
+// with that encoding as the basis.
+func FromBinaryBasis[E Element[E]](m, basis matrix.Matrix) (MatrixOf[E], bool) {
+	n, p := m.Size()
+	k := fieldDegree[E]()
+
+	if a, b := basis.Size(); a != k || b != k {
+		panic("Can't use basis that is wrong size!")
+	} else if n%k != 0 || p%k != 0 {
+		return nil, false
+	}
+
+	inv, ok := basis.Invert()
+	if !ok {
+		panic("Can't use basis that isn't invertible!")
+	}
+
+	// Write the input and output in the polynomial basis.
+	out, in := blockDiagonal(inv, k, n/k), blockDiagonal(basis, k, p/k)
+
+	return FromBinary[E](out.Compose(m).Compose(in))
+}
+
+// FindBasis searches for a basis of the field that makes the square binary matrix m E-linear, as in FromBinaryBasis.
+// It returns the basis and the matrix over E, or false if it found neither. E must be a field of degree 8.
+//
+// m is E-linear in some basis exactly when it commutes with multiplication by x written in that basis, applied to every
+// element at once. All matrices that commute with m that way form a space, so candidates from that space are checked
+// until one looks like multiplication by x: every candidate if there are no more than tries of them, and otherwise
+// tries random ones drawn with the random source reader.
+func FindBasis[E Element[E]](reader io.Reader, m matrix.Matrix, tries int) (basis matrix.Matrix, out MatrixOf[E], ok bool) {
+	n, p := m.Size()
+	k := fieldDegree[E]()
+
+	if k != 8 {
+		panic("Can't search for basis of field whose degree isn't 8!")
+	} else if n != p || n%k != 0 {
+		return nil, nil, false
+	}
+
+	space := commutingBlocks(m, k)
+
+	// Multiplication by x, in any basis, is a root of E's modulus.
+	var zero E
+	degrees := []int{}
+	for i := 0; i <= k; i++ {
+		if zero.Modulus()>>uint(i)&1 == 1 {
+			degrees = append(degrees, i)
+		}
+	}
+
+	modulus, x := matrix.NewPolynomial(degrees...), MatrixOf[E]{{2}}.Binary()
+
+	try := func(coeffs *big.Int) bool {
+		cand := matrix.GenerateEmpty(k, k)
+		for i, v := range space {
+			if coeffs.Bit(i) == 1 {
+				cand = cand.Add(v)
+			}
+		}
+
+		if !isZeroMatrix(modulus.Eval(cand)) {
+			return false
+		}
+
+		// cand is multiplication by x in the basis that takes the polynomial basis to it.
+		basis, ok = cand.Conjugator(x)
+		if ok {
+			out, ok = FromBinaryBasis[E](m, basis)
+		}
+
+		return ok
+	}
+
+	total := new(big.Int).Lsh(big.NewInt(1), uint(len(space)))
+	if total.Cmp(big.NewInt(int64(tries))) <= 0 {
+		for coeffs := big.NewInt(1); coeffs.Cmp(total) < 0; coeffs.Add(coeffs, big.NewInt(1)) {
+			if try(coeffs) {
+				return basis, out, true
+			}
+		}
+
+		return nil, nil, false
+	}
+
+	buf := make([]byte, (len(space)+7)/8)
+	for t := 0; t < tries; t++ {
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			break
+		}
+
+		coeffs := new(big.Int).SetBytes(buf)
+		coeffs.Mod(coeffs, total)
+
+		if try(coeffs) {
+			return basis, out, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+// commutingBlocks returns a basis for the space of k-by-k binary matrices a such that m commutes with the block
+// diagonal matrix with a in every block.
+func commutingBlocks(m matrix.Matrix, k int) []matrix.Matrix {
+	n, _ := m.Size()
+
+	// Row k*r + s of the system is the commutator of m with the block diagonal matrix of the unit matrix at (r, s).
+	system := matrix.GenerateEmpty(k*k, n*n)
+	for r := 0; r < k; r++ {
+		for s := 0; s < k; s++ {
+			unit := matrix.GenerateEmpty(k, k)
+			unit[r].SetBit(s, true)
+
+			diag := blockDiagonal(unit, k, n/k)
+			system[k*r+s] = m.Compose(diag).Add(diag.Compose(m)).Vec()
+		}
+	}
+
+	space := []matrix.Matrix{}
+	for _, coeffs := range system.Transpose().NullSpace() {
+		a := matrix.GenerateEmpty(k, k)
+		for i := 0; i < k*k; i++ {
+			a[i/k].SetBit(i%k, coeffs.GetBit(i) == 1)
+		}
+
+		space = append(space, a)
+	}
+
+	return space
+}
+
+// blockDiagonal returns the block diagonal binary matrix with count copies of the k-by-k matrix block on its diagonal.
+func blockDiagonal(block matrix.Matrix, k, count int) matrix.Matrix {
+	out := matrix.GenerateEmpty(k*count, k*count)
+
+	for i := 0; i < count; i++ {
+		for r := 0; r < k; r++ {
+			for c := 0; c < k; c++ {
+				out[i*k+r].SetBit(i*k+c, block[r].GetBit(c) == 1)
+			}
+		}
+	}
+
+	return out
+}
+
+// isZeroMatrix returns true if every entry of the binary matrix is zero.
+func isZeroMatrix(m matrix.Matrix) bool {
+	for _, row := range m {
+		if !row.IsZero() {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,61 @@
+package gfmatrix
+
+import (
+	"crypto/rand"
+	"testing"
+
+	mrand "math/rand"
+
+	"github.com/OpenWhiteBox/primitives/matrix"
+	"github.com/OpenWhiteBox/primitives/number"
+)
+
+func TestBinary(t *testing.T) {
+	m, _ := GenerateRandom(rand.Reader, 4)
+	x := GenerateRandomRow(rand.Reader, 4)
+
+	if !m.Binary().Mul(x.Binary()).Equals(m.Mul(x).Binary()) {
+		t.Fatal("Binary matrix disagrees with matrix over GF(2^8).")
+	}
+
+	n, _ := GenerateRandomOf[nibble](rand.Reader, 4)
+	y := GenerateRandomRowOf[nibble](rand.Reader, 4)
+
+	if !n.Binary().Mul(y.Binary()).Equals(n.Mul(y).Binary()) {
+		t.Fatal("Binary matrix disagrees with matrix over GF(2^4).")
+	}
+
+	if parsed, ok := FromBinary[number.ByteFieldElem](m.Binary()); !ok || !parsed.Equals(m) {
+		t.Fatal("FromBinary didn't recover the original matrix.")
+	} else if parsed, ok := FromBinary[nibble](n.Binary()); !ok || !parsed.Equals(n) {
+		t.Fatal("FromBinary didn't recover the original matrix over GF(2^4).")
+	}
+
+	// A random binary matrix is almost never linear over GF(2^8).
+	if _, ok := FromBinary[number.ByteFieldElem](matrix.GenerateRandom(rand.Reader, 32)); ok {
+		t.Fatal("FromBinary accepted a random binary matrix.")
+	}
+}
+
+func TestFindBasis(t *testing.T) {
+	// Hide MixColumns by writing every byte of its input and output in a random basis.
+	basis := matrix.GenerateRandom(mrand.New(mrand.NewSource(1)), 8)
+	basisInv, _ := basis.Invert()
+
+	hidden := blockDiagonal(basis, 8, 4).Compose(mixColumns.Binary()).Compose(blockDiagonal(basisInv, 8, 4))
+
+	if _, ok := FromBinary[number.ByteFieldElem](hidden); ok {
+		t.Fatal("FromBinary saw through the hidden basis.")
+	} else if m, ok := FromBinaryBasis[number.ByteFieldElem](hidden, basis); !ok || !m.Equals(mixColumns) {
+		t.Fatal("FromBinaryBasis didn't recover MixColumns.")
+	}
+
+	found, m, ok := FindBasis[number.ByteFieldElem](mrand.New(mrand.NewSource(2)), hidden, 1<<12)
+	if !ok {
+		t.Fatal("FindBasis failed to find a basis.")
+	} else if again, ok := FromBinaryBasis[number.ByteFieldElem](hidden, found); !ok || !again.Equals(m) {
+		t.Fatal("FindBasis returned an inconsistent basis and matrix.")
+	} else if !m.IsMDS() || !m.Equals(GenerateCirculant(m[0])) {
+		t.Fatalf("FindBasis returned a matrix that doesn't look like MixColumns:\n%v", m)
+	}
+}
@@ -0,0 +1,252 @@
+package gfmatrix
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// BranchOptions configures a branch number computation. The zero value computes the exact branch number in one
+// goroutine.
+type BranchOptions struct {
+	// Bound, if non-zero, stops the search as soon as the branch number is known to be less than Bound. The number
+	// returned is then an upper bound on the branch number that's less than Bound, so comparing it to Bound is exact.
+	Bound int
+	// Workers is the number of goroutines to search with. Zero means one.
+	Workers int
+}
+
+// DifferentialBranchNumber returns the differential branch number of the matrix: the minimum of wt(x) + wt(M x) over
+// all non-zero x, where wt is the number of non-zero entries in a vector.
+//
+// Inputs are searched in order of increasing weight, and because scaling an input doesn't change either weight, only
+// inputs whose first non-zero entry is 1 are tried. The search stops as soon as no heavier input could give a smaller
+// branch number, but it's still exponential in the branch number; use IsMDS to test large matrices for the maximum.
+func (e MatrixOf[E]) DifferentialBranchNumber(opts BranchOptions) int {
+	out, in := e.Size()
+
+	bs := &branchSearch[E]{
+		cells: in,
+		out:   out,
+		opts:  opts,
+		best:  math.MaxInt32,
+	}
+
+	// An injective matrix never sends a non-zero input to zero.
+	if out >= in && len(e.NullSpace()) == 0 {
+		bs.minOut = 1
+	}
+
+	// table[p][v] is the output of the matrix on the input that's v in position p and zero everywhere else.
+	bs.q = fieldSize[E]()
+	bs.table = make([]E, in*bs.q*out)
+	for p := 0; p < in; p++ {
+		for v := 1; v < bs.q; v++ {
+			dst := bs.entry(p, v)
+			for i := 0; i < out; i++ {
+				dst[i] = e[i][p].Mul(E(v))
+			}
+		}
+	}
+
+	return bs.run()
+}
+
+// LinearBranchNumber returns the linear branch number of the matrix: the minimum of wt(x) + wt(M^T x) over all non-zero
+// x. See DifferentialBranchNumber.
+func (e MatrixOf[E]) LinearBranchNumber(opts BranchOptions) int {
+	return e.Transpose().DifferentialBranchNumber(opts)
+}
+
+// IsMDS returns true if every square submatrix of the matrix is non-singular. For an n-by-n matrix, this is the same as
+// having the largest possible branch number, n+1, but it's much cheaper to check.
+func (e MatrixOf[E]) IsMDS() bool {
+	out, in := e.Size()
+
+	k := in
+	if out < in {
+		k = out
+	}
+
+	scratch := GenerateEmptyOf[E](k, k)
+
+	for size := 1; size <= k; size++ {
+		ok := combinations(out, size, func(rows []int) bool {
+			return combinations(in, size, func(cols []int) bool {
+				return e.nonSingular(rows, cols, scratch)
+			})
+		})
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// nonSingular returns true if the submatrix of e on the given rows and columns is non-singular. It overwrites scratch.
+func (e MatrixOf[E]) nonSingular(rows, cols []int, scratch MatrixOf[E]) bool {
+	n := len(rows)
+	f := scratch[:n]
+
+	for i, row := range rows {
+		f[i] = f[i][:n]
+		for j, col := range cols {
+			f[i][j] = e[row][col]
+		}
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := f.FindPivot(col, col)
+		if pivot == -1 {
+			return false
+		}
+		f[col], f[pivot] = f[pivot], f[col]
+
+		correction := f[col][col].Invert()
+		for j := col + 1; j < n; j++ {
+			if !f[j][col].IsZero() {
+				c := f[j][col].Mul(correction)
+				for k := col; k < n; k++ {
+					f[j][k] = f[j][k].Add(f[col][k].Mul(c))
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// combinations calls f on every size-k subset of {0, ..., n-1}, in increasing order, until f returns false. It returns
+// false if f ever did.
+func combinations(n, k int, f func([]int) bool) bool {
+	set := make([]int, k)
+	for i := range set {
+		set[i] = i
+	}
+
+	for {
+		if !f(set) {
+			return false
+		}
+
+		// Advance to the next subset in lexicographic order.
+		i := k - 1
+		for i >= 0 && set[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return true
+		}
+
+		set[i]++
+		for j := i + 1; j < k; j++ {
+			set[j] = set[j-1] + 1
+		}
+	}
+}
+
+// branchSearch holds the state of a branch number computation that's shared between workers.
+type branchSearch[E Element[E]] struct {
+	cells, out int
+	q          int // The size of the field.
+	table      []E
+	minOut     int // A lower bound on the weight of the output of a non-zero input.
+
+	opts BranchOptions
+	best int64 // The smallest weight found so far. Accessed atomically.
+}
+
+// entry returns the output of the matrix on the input that's v in position p.
+func (bs *branchSearch[E]) entry(p, v int) []E {
+	return bs.table[(p*bs.q+v)*bs.out : (p*bs.q+v+1)*bs.out]
+}
+
+// done returns true if no input of weight w or more can change the answer.
+func (bs *branchSearch[E]) done(w int) bool {
+	best := int(atomic.LoadInt64(&bs.best))
+	return w+bs.minOut >= best || bs.opts.Bound > 0 && best < bs.opts.Bound
+}
+
+// update records that an input-output pair of total weight w was found.
+func (bs *branchSearch[E]) update(w int) {
+	for {
+		best := atomic.LoadInt64(&bs.best)
+		if int64(w) >= best || atomic.CompareAndSwapInt64(&bs.best, best, int64(w)) {
+			return
+		}
+	}
+}
+
+// run searches inputs of each weight in turn, splitting each weight between workers by the position of the first
+// non-zero entry.
+func (bs *branchSearch[E]) run() int {
+	workers := bs.opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	for w := 1; w <= bs.cells && !bs.done(w); w++ {
+		work := make(chan int)
+		wg := sync.WaitGroup{}
+
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				acc := make([][]E, w)
+				for k := range acc {
+					acc[k] = make([]E, bs.out)
+				}
+
+				for p := range work {
+					copy(acc[0], bs.entry(p, 1))
+					bs.search(w, 1, p+1, acc)
+				}
+			}()
+		}
+
+		for p := 0; p <= bs.cells-w && !bs.done(w); p++ {
+			work <- p
+		}
+
+		close(work)
+		wg.Wait()
+	}
+
+	return int(bs.best)
+}
+
+// search enumerates every input of weight w whose first depth entries have been chosen and accumulated into acc, with
+// the remaining entries at position from or later.
+func (bs *branchSearch[E]) search(w, depth, from int, acc [][]E) {
+	if depth == w {
+		weight := w
+		for _, y := range acc[depth-1] {
+			if !y.IsZero() {
+				weight++
+			}
+		}
+
+		bs.update(weight)
+		return
+	}
+
+	for p := from; p <= bs.cells-(w-depth); p++ {
+		if bs.done(w) {
+			return
+		}
+
+		for v := 1; v < bs.q; v++ {
+			prev, next, src := acc[depth-1], acc[depth], bs.entry(p, v)
+			for j := range next {
+				next[j] = prev[j].Add(src[j])
+			}
+
+			bs.search(w, depth+1, p+1, acc)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package gfmatrix
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+// mixColumns is AES's MixColumns matrix.
+var mixColumns = Matrix{
+	Row{2, 3, 1, 1},
+	Row{1, 2, 3, 1},
+	Row{1, 1, 2, 3},
+	Row{3, 1, 1, 2},
+}
+
+func TestBranchNumber(t *testing.T) {
+	if b := mixColumns.DifferentialBranchNumber(BranchOptions{}); b != 5 {
+		t.Fatalf("MixColumns had differential branch number %v, not 5.", b)
+	} else if b := mixColumns.LinearBranchNumber(BranchOptions{Workers: 4}); b != 5 {
+		t.Fatalf("MixColumns had linear branch number %v, not 5.", b)
+	} else if b := mixColumns.DifferentialBranchNumber(BranchOptions{Bound: 4}); b < 4 {
+		t.Fatalf("Bounded search returned %v, which is below the bound.", b)
+	} else if !mixColumns.IsMDS() {
+		t.Fatal("MixColumns isn't MDS.")
+	}
+
+	// The last input is sent to zero, so it has weight 1 on its own.
+	singular := mixColumns.Dup()
+	for i, _ := range singular {
+		singular[i][3] = 0
+	}
+
+	if b := singular.DifferentialBranchNumber(BranchOptions{}); b != 1 {
+		t.Fatalf("Singular matrix had differential branch number %v, not 1.", b)
+	}
+}
+
+func TestIsMDS(t *testing.T) {
+	// Entries from a small set make singular submatrices common.
+	for i := 0; i < 50; i++ {
+		m := GenerateEmpty(3, 3)
+		for j := 0; j < 9; j++ {
+			m[j/3][j%3] = GenerateRandomRow(rand.Reader, 1)[0] & 0x03
+		}
+
+		mds := m.DifferentialBranchNumber(BranchOptions{Bound: 4}) == 4
+		if m.IsMDS() != mds {
+			t.Fatalf("IsMDS returned %v for matrix where branch number test returned %v:\n%v", !mds, mds, m)
+		}
+	}
+}
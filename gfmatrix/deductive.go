@@ -1,25 +1,61 @@
 package gfmatrix
 
-// DeductiveMatrix is a generalization of IncrementalMatrix that allows the incremental deduction of matrices.
+import (
+	"github.com/OpenWhiteBox/primitives/number"
+)
+
+// DeductiveMatrixOf is a generalization of IncrementalMatrixOf that allows the incremental deduction of matrices over the
+// field E.
 //
 // Like incremental matrices, its primary use-case is in cryptanalyses and search algorithms, where we can do some work
 // to obtain an (input, output) pair that we believe defines a matrix. We don't want to do more work than necessary and
 // we also can't just obtain n (input, output) pairs because of linear dependence, etc.
-type DeductiveMatrix struct {
-	input, output IncrementalMatrix
+type DeductiveMatrixOf[E Element[E]] struct {
+	input, output IncrementalMatrixOf[E]
+}
+
+// DeductiveMarker identifies a point in the history of a deductive matrix that it can be rolled back to.
+type DeductiveMarker struct {
+	input, output Marker
 }
 
+// DeductiveMatrix is a deductive matrix over GF(2^8).
+type DeductiveMatrix = DeductiveMatrixOf[number.ByteFieldElem]
+
 // NewDeductiveMatrix returns a new n-by-n deductive matrix.
 func NewDeductiveMatrix(n int) DeductiveMatrix {
-	return DeductiveMatrix{
-		input:  NewIncrementalMatrix(n),
-		output: NewIncrementalMatrix(n),
+	return NewDeductiveMatrixOf[number.ByteFieldElem](n)
+}
+
+// NewDeductiveMatrixOf returns a new n-by-n deductive matrix over the field E.
+func NewDeductiveMatrixOf[E Element[E]](n int) DeductiveMatrixOf[E] {
+	return DeductiveMatrixOf[E]{
+		input:  NewIncrementalMatrixOf[E](n),
+		output: NewIncrementalMatrixOf[E](n),
 	}
 }
 
 // Assert represents an assertion that A(in) = out. The function will panic if this is inconsistent with previous
 // assertions. It it's not, it returns whether or not the assertion contained new information about A.
-func (dm *DeductiveMatrix) Assert(in, out Row) (learned bool) {
+func (dm *DeductiveMatrixOf[E]) Assert(in, out RowOf[E]) (learned bool) {
+	learned, err := dm.CheckedAssert(in, out)
+	if err == ErrInconsistentAssertion {
+		panic("Asserted input, output pair is inconsistent with previous assertions!")
+	} else if err != nil {
+		panic("Tried to reduce incorrectly sized row with incremental matrix!")
+	}
+
+	return learned
+}
+
+// CheckedAssert represents an assertion that A(in) = out. It returns ErrInconsistentAssertion if this is inconsistent
+// with previous assertions and ErrDimensionMismatch if either row is the wrong size; A is left unchanged in both cases.
+// Otherwise, it returns whether or not the assertion contained new information about A.
+func (dm *DeductiveMatrixOf[E]) CheckedAssert(in, out RowOf[E]) (learned bool, err error) {
+	if in.Size() != dm.input.n || out.Size() != dm.output.n {
+		return false, ErrDimensionMismatch
+	}
+
 	inReduced, inInverse := dm.input.reduce(in)
 	outReduced, outInverse := dm.output.reduce(out)
 
@@ -27,43 +63,43 @@ func (dm *DeductiveMatrix) Assert(in, out Row) (learned bool) {
 		real := dm.output.Matrix().Transpose().Mul(inInverse)
 
 		if !real.Equals(out) {
-			panic("Asserted input, output pair is inconsistent with previous assertions!")
+			return false, ErrInconsistentAssertion
 		}
-		return false
+		return false, nil
 	}
 
 	dm.input.addRows(in, inReduced, inInverse)
 	dm.output.addRows(out, outReduced, outInverse)
-	return true
+	return true, nil
 }
 
 // FullyDefined returns true if the assertions made give a fully defined matrix.
-func (dm *DeductiveMatrix) FullyDefined() bool {
+func (dm *DeductiveMatrixOf[E]) FullyDefined() bool {
 	return dm.input.FullyDefined() && dm.output.FullyDefined()
 }
 
 // NovelInput returns a random x not in the domain of A.
-func (dm *DeductiveMatrix) NovelInput() Row {
+func (dm *DeductiveMatrixOf[E]) NovelInput() RowOf[E] {
 	return dm.input.Novel()
 }
 
 // NovelOutput returns a random y not in the span of A.
-func (dm *DeductiveMatrix) NovelOutput() Row {
+func (dm *DeductiveMatrixOf[E]) NovelOutput() RowOf[E] {
 	return dm.output.Novel()
 }
 
 // IsInDomain returns whether or not x is in the known span of A.
-func (dm *DeductiveMatrix) IsInDomain(x Row) bool {
+func (dm *DeductiveMatrixOf[E]) IsInDomain(x RowOf[E]) bool {
 	return dm.input.IsInSpan(x)
 }
 
 // IsInSpan returns whether or not y is in the known span of A.
-func (dm *DeductiveMatrix) IsInSpan(y Row) bool {
+func (dm *DeductiveMatrixOf[E]) IsInSpan(y RowOf[E]) bool {
 	return dm.output.IsInSpan(y)
 }
 
 // Matrix returns the deduced matrix.
-func (dm *DeductiveMatrix) Matrix() Matrix {
+func (dm *DeductiveMatrixOf[E]) Matrix() MatrixOf[E] {
 	if !dm.FullyDefined() {
 		return nil
 	}
@@ -71,16 +107,35 @@ func (dm *DeductiveMatrix) Matrix() Matrix {
 }
 
 // Inverse returns the deduced matrix's inverse.
-func (dm *DeductiveMatrix) Inverse() Matrix {
+func (dm *DeductiveMatrixOf[E]) Inverse() MatrixOf[E] {
 	if !dm.FullyDefined() {
 		return nil
 	}
 	return dm.output.Inverse().Compose(dm.input.Matrix()).Transpose()
 }
 
+// Checkpoint returns a marker for the current state of the deductive matrix, which can be passed to Rollback to undo
+// every assertion made after it.
+func (dm *DeductiveMatrixOf[E]) Checkpoint() DeductiveMarker {
+	return DeductiveMarker{dm.input.Checkpoint(), dm.output.Checkpoint()}
+}
+
+// Rollback undoes every assertion made since the given marker was returned by Checkpoint.
+func (dm *DeductiveMatrixOf[E]) Rollback(marker DeductiveMarker) {
+	dm.input.Rollback(marker.input)
+	dm.output.Rollback(marker.output)
+}
+
+// Commit keeps every assertion made since the given marker was returned by Checkpoint. Every checkpoint should be
+// rolled back or committed, so that the deductive matrix can stop logging changes.
+func (dm *DeductiveMatrixOf[E]) Commit(marker DeductiveMarker) {
+	dm.input.Commit(marker.input)
+	dm.output.Commit(marker.output)
+}
+
 // Dup returns a duplicate of dm.
-func (dm *DeductiveMatrix) Dup() DeductiveMatrix {
-	return DeductiveMatrix{
+func (dm *DeductiveMatrixOf[E]) Dup() DeductiveMatrixOf[E] {
+	return DeductiveMatrixOf[E]{
 		input:  dm.input.Dup(),
 		output: dm.output.Dup(),
 	}
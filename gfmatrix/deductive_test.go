@@ -104,3 +104,34 @@ func TestDeductiveMatrixFailure3(t *testing.T) {
 	dm.Assert(in, out)
 	t.Fatal("Goroutine did not panic when it should've!")
 }
+
+func TestDeductiveMatrixCheckedAssert(t *testing.T) {
+	dm := testingDeductiveMatrix()
+
+	// Generate a random un-novel input element and a random novel output element.
+	in := dm.input.Matrix().Transpose().Mul(GenerateRandomRow(rand.Reader, 16))
+	out := dm.NovelOutput()
+
+	if _, err := dm.CheckedAssert(in, out); err != ErrInconsistentAssertion {
+		t.Fatalf("CheckedAssert didn't report inconsistent assertion: %v", err)
+	} else if _, err := dm.CheckedAssert(NewRow(8), out); err != ErrDimensionMismatch {
+		t.Fatalf("CheckedAssert didn't report wrong size row: %v", err)
+	} else if dm.input.Len() != 14 || dm.output.Len() != 14 {
+		t.Fatal("CheckedAssert mutated state on failed assertion.")
+	}
+}
+
+func TestDeductiveMatrixRollback(t *testing.T) {
+	dm := testingDeductiveMatrix()
+	mark := dm.Checkpoint()
+
+	// Finish the matrix off with a wrong guess, then undo it.
+	for !dm.FullyDefined() {
+		dm.Assert(dm.NovelInput(), dm.NovelOutput())
+	}
+	dm.Rollback(mark)
+
+	if dm.FullyDefined() || len(dm.input.raw) != 14 || len(dm.output.raw) != 14 {
+		t.Fatalf("Rollback didn't undo assertions.")
+	}
+}
@@ -0,0 +1,222 @@
+package gfmatrix
+
+import (
+	"github.com/OpenWhiteBox/primitives/number"
+)
+
+// ExtensionEigenvalueOf is an eigenvalue of a matrix over E that isn't in E, but in the extension field E[y]/(Field).
+type ExtensionEigenvalueOf[E Element[E]] struct {
+	Field        PolynomialOf[E] // An irreducible factor of the characteristic polynomial of degree more than one.
+	Value        PolynomialOf[E] // The eigenvalue, as a polynomial in y of degree less than Field's.
+	Multiplicity int             // The algebraic multiplicity of the eigenvalue.
+}
+
+// ExtensionEigenvalue is an eigenvalue of a matrix over GF(2^8) that lies in an extension field.
+type ExtensionEigenvalue = ExtensionEigenvalueOf[number.ByteFieldElem]
+
+// CharPoly returns the characteristic polynomial of a square matrix, det(xI + M).
+func (e MatrixOf[E]) CharPoly() PolynomialOf[E] {
+	n, m := e.Size()
+	if n != m {
+		panic("Can't take characteristic polynomial of non-square matrix!")
+	}
+
+	h := e.hessenberg()
+	x := monomial(E(1), 1)
+
+	// p[k] is the characteristic polynomial of the top-left k-by-k submatrix of h.
+	p := make([]PolynomialOf[E], n+1)
+	p[0] = PolynomialOf[E]{1}
+
+	for k := 1; k <= n; k++ {
+		p[k] = p[k-1].Mul(x.Add(PolynomialOf[E]{h[k-1][k-1]}))
+
+		// Expand along the last column, keeping the product of the subdiagonal entries from row i down.
+		prod := E(1)
+		for i := k - 1; i >= 1; i-- {
+			if prod = prod.Mul(h[i][i-1]); prod.IsZero() {
+				break
+			}
+
+			if !h[i-1][k-1].IsZero() {
+				p[k] = p[k].Add(p[i-1].ScalarMul(prod.Mul(h[i-1][k-1])))
+			}
+		}
+	}
+
+	return p[n]
+}
+
+// hessenberg returns a matrix similar to e that's zero below the subdiagonal.
+func (e MatrixOf[E]) hessenberg() MatrixOf[E] {
+	n, _ := e.Size()
+	h := e.Dup()
+
+	for j := 0; j < n-2; j++ {
+		i := h.FindPivot(j+1, j)
+		if i == -1 {
+			continue
+		} else if i != j+1 {
+			// Swap rows and then columns, so h stays similar to e.
+			h[i], h[j+1] = h[j+1], h[i]
+			for _, row := range h {
+				row[i], row[j+1] = row[j+1], row[i]
+			}
+		}
+
+		inv := h[j+1][j].Invert()
+		for r := j + 2; r < n; r++ {
+			if h[r][j].IsZero() {
+				continue
+			}
+
+			// Adding c times row j+1 into row r is undone by adding c times column r into column j+1.
+			c := h[r][j].Mul(inv)
+			h[r] = h[r].Add(h[j+1].ScalarMul(c))
+
+			for _, row := range h {
+				row[j+1] = row[j+1].Add(row[r].Mul(c))
+			}
+		}
+	}
+
+	return h
+}
+
+// MinPoly returns the minimal polynomial of a square matrix: the monic polynomial p of least degree with p(M) = 0.
+func (e MatrixOf[E]) MinPoly() PolynomialOf[E] {
+	n, _ := e.Size()
+	out := PolynomialOf[E]{1}
+
+	for _, factor := range e.CharPoly().Factor() {
+		// The kernel of f(M)^k grows with k until it's the generalized eigenspace of f, which has dimension
+		// deg(f) * multiplicity. The number of steps it takes is f's multiplicity in the minimal polynomial.
+		target := n - factor.Poly.Degree()*factor.Multiplicity
+		f, power := factor.Poly.Eval(e), GenerateIdentityOf[E](n)
+
+		for power.Rank() > target {
+			power = power.Compose(f)
+			out = out.Mul(factor.Poly)
+		}
+	}
+
+	return out
+}
+
+// Rank returns the dimension of the matrix's row space.
+func (e MatrixOf[E]) Rank() int {
+	out, in := e.Size()
+	if out == 0 {
+		return 0
+	}
+
+	_, _, frees := e.gaussJordan()
+	return in - len(frees)
+}
+
+// Determinant returns the determinant of a square matrix.
+func (e MatrixOf[E]) Determinant() E {
+	n, m := e.Size()
+	if n != m {
+		panic("Can't take determinant of non-square matrix!")
+	}
+
+	f, out := e.Dup(), E(1)
+
+	for col := 0; col < n; col++ {
+		pivot := f.FindPivot(col, col)
+		if pivot == -1 {
+			return 0
+		}
+
+		// Swapping rows negates the determinant, which does nothing in characteristic 2.
+		f[col], f[pivot] = f[pivot], f[col]
+		out = out.Mul(f[col][col])
+
+		inv := f[col][col].Invert()
+		for i := col + 1; i < n; i++ {
+			if !f[i][col].IsZero() {
+				f[i] = f[i].Add(f[col].ScalarMul(f[i][col].Mul(inv)))
+			}
+		}
+	}
+
+	return out
+}
+
+// Adjugate returns the adjugate of a square matrix: the transpose of its matrix of cofactors, so that
+// M * adj(M) = det(M) * I, even if M is singular.
+func (e MatrixOf[E]) Adjugate() MatrixOf[E] {
+	n, m := e.Size()
+	if n != m {
+		panic("Can't take adjugate of non-square matrix!")
+	}
+
+	if inv, ok := e.Invert(); ok {
+		det := e.Determinant()
+		for i, row := range inv {
+			inv[i] = row.ScalarMul(det)
+		}
+
+		return inv
+	}
+
+	// Entry (i, j) is the determinant of e without row j and column i. Signs don't matter in characteristic 2.
+	out := GenerateEmptyOf[E](n, n)
+	minor := GenerateEmptyOf[E](n-1, n-1)
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			for r, row := range e[:j] {
+				copy(minor[r], row[:i])
+				copy(minor[r][i:], row[i+1:])
+			}
+			for r, row := range e[j+1:] {
+				copy(minor[j+r], row[:i])
+				copy(minor[j+r][i:], row[i+1:])
+			}
+
+			out[i][j] = minor.Determinant()
+		}
+	}
+
+	return out
+}
+
+// Eigenvalues returns the distinct eigenvalues of a square matrix that are in E, in increasing order. See
+// ExtensionEigenvalues for the rest.
+func (e MatrixOf[E]) Eigenvalues() []E {
+	return e.CharPoly().Roots()
+}
+
+// ExtensionEigenvalues returns the eigenvalues of a square matrix that aren't in E. Each irreducible factor f of the
+// characteristic polynomial with degree d > 1 contributes d conjugate eigenvalues in E[y]/(f): y, y^q, y^(q^2), and so
+// on, where q is the size of E. The subspace of E^n they act on is given by Kernel(f).
+func (e MatrixOf[E]) ExtensionEigenvalues() (out []ExtensionEigenvalueOf[E]) {
+	for _, factor := range e.CharPoly().Factor() {
+		d := factor.Poly.Degree()
+		if d == 1 {
+			continue
+		}
+
+		value := monomial(E(1), 1)
+		for i := 0; i < d; i++ {
+			out = append(out, ExtensionEigenvalueOf[E]{factor.Poly, value, factor.Multiplicity})
+			value = frobenius(value, factor.Poly)
+		}
+	}
+
+	return
+}
+
+// Eigenspace returns a basis for the space of vectors x with M * x = lambda * x. It's empty if lambda isn't an
+// eigenvalue.
+func (e MatrixOf[E]) Eigenspace(lambda E) []RowOf[E] {
+	return e.Kernel(PolynomialOf[E]{lambda, 1})
+}
+
+// Kernel returns a basis for the null space of p(M). With p an irreducible factor of the characteristic polynomial, this
+// is the E-rational subspace spanned by the eigenvectors of p's roots.
+func (e MatrixOf[E]) Kernel(p PolynomialOf[E]) []RowOf[E] {
+	return p.Eval(e).NullSpace()
+}
@@ -0,0 +1,177 @@
+package gfmatrix
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/OpenWhiteBox/primitives/number"
+)
+
+// isZero returns true if every entry of the matrix is zero.
+func isZero[E Element[E]](m MatrixOf[E]) bool {
+	for _, row := range m {
+		if !row.IsZero() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestCharPoly(t *testing.T) {
+	m := GenerateTrueRandom(rand.Reader, 6)
+	p := m.CharPoly()
+
+	if p.Degree() != 6 || !p[6].IsOne() {
+		t.Fatalf("CharPoly returned polynomial that isn't monic of degree 6: %v", p)
+	} else if !isZero(p.Eval(m)) {
+		t.Fatal("Matrix isn't a root of its characteristic polynomial.")
+	}
+
+	// p(lambda) = det(lambda * I + M)
+	for x := 0; x < 256; x++ {
+		lambda := number.ByteFieldElem(x)
+
+		shifted := m.Dup()
+		for i, _ := range shifted {
+			shifted[i][i] = shifted[i][i].Add(lambda)
+		}
+
+		if p.EvalElem(lambda) != shifted.Determinant() {
+			t.Fatalf("CharPoly disagrees with Determinant at %x.", x)
+		}
+	}
+}
+
+func TestMinPoly(t *testing.T) {
+	p, pInv := GenerateRandom(rand.Reader, 4)
+	d := Matrix{
+		Row{3, 0, 0, 0},
+		Row{0, 3, 0, 0},
+		Row{0, 0, 5, 1},
+		Row{0, 0, 0, 5},
+	}
+	m := p.Compose(d).Compose(pInv)
+
+	// The eigenvalue 3 has a diagonal block and 5 has a Jordan block.
+	real := Polynomial{3, 1}.Mul(Polynomial{5, 1}).Mul(Polynomial{5, 1})
+	if minPoly := m.MinPoly(); !minPoly.Equals(real) {
+		t.Fatalf("MinPoly returned %v, not %v.", minPoly, real)
+	}
+
+	if vals := m.Eigenvalues(); len(vals) != 2 || vals[0] != 3 || vals[1] != 5 {
+		t.Fatalf("Eigenvalues returned %v, not [3 5].", vals)
+	} else if len(m.ExtensionEigenvalues()) != 0 {
+		t.Fatal("ExtensionEigenvalues returned eigenvalues of a matrix with all of them in the field.")
+	}
+
+	for _, lambda := range []number.ByteFieldElem{3, 5} {
+		basis := m.Eigenspace(lambda)
+		if dim := map[number.ByteFieldElem]int{3: 2, 5: 1}[lambda]; len(basis) != dim {
+			t.Fatalf("Eigenspace of %x had dimension %v, not %v.", lambda, len(basis), dim)
+		}
+
+		for _, x := range basis {
+			if !m.Mul(x).Equals(x.ScalarMul(lambda)) {
+				t.Fatalf("Eigenspace of %x contains a vector that isn't an eigenvector.", lambda)
+			}
+		}
+	}
+
+	if len(m.Eigenspace(7)) != 0 {
+		t.Fatal("Eigenspace of a non-eigenvalue isn't empty.")
+	}
+}
+
+func TestDeterminant(t *testing.T) {
+	a, b := GenerateTrueRandom(rand.Reader, 5), GenerateTrueRandom(rand.Reader, 5)
+
+	if a.Compose(b).Determinant() != a.Determinant().Mul(b.Determinant()) {
+		t.Fatal("Determinant isn't multiplicative.")
+	}
+
+	// Adjugates of invertible, rank n-1, and rank n-2 matrices.
+	for rank := 5; rank >= 3; rank-- {
+		m, _ := GenerateRandom(rand.Reader, 5)
+		for i := rank; i < 5; i++ {
+			m[i] = m[0].ScalarMul(number.ByteFieldElem(i))
+		}
+
+		product := m.Compose(m.Adjugate())
+		for i, row := range product {
+			row[i] = row[i].Add(m.Determinant())
+		}
+
+		if !isZero(product) {
+			t.Fatalf("M * adj(M) != det(M) * I for matrix of rank %v.", rank)
+		} else if rank == 3 && !isZero(m.Adjugate()) {
+			t.Fatal("Adjugate of matrix of rank n-2 isn't zero.")
+		}
+	}
+}
+
+func TestFactor(t *testing.T) {
+	// x^16 + x splits into every linear polynomial over GF(2^4).
+	p := monomial(nibble(1), 16).Add(monomial(nibble(1), 1))
+
+	factors := p.Factor()
+	if len(factors) != 16 {
+		t.Fatalf("x^16 + x had %v factors over GF(2^4), not 16.", len(factors))
+	}
+
+	for i, f := range factors {
+		if !f.Poly.Equals(PolynomialOf[nibble]{nibble(i), 1}) || f.Multiplicity != 1 {
+			t.Fatalf("Factor %v of x^16 + x was %v, not x + %x.", i, f.Poly, i)
+		}
+	}
+
+	// (x + 1)^2 (x^2 + x + c), where c makes the second factor irreducible.
+	q := irreducibleQuadratic()
+	p2 := Polynomial{1, 1}.Mul(Polynomial{1, 1}).Mul(q).ScalarMul(0x07)
+
+	factors2 := p2.Factor()
+	if len(factors2) != 2 || !factors2[0].Poly.Equals(Polynomial{1, 1}) || factors2[0].Multiplicity != 2 ||
+		!factors2[1].Poly.Equals(q) || factors2[1].Multiplicity != 1 {
+		t.Fatalf("Factor returned %v.", factors2)
+	} else if !q.IsIrreducible() || p2.IsIrreducible() {
+		t.Fatal("IsIrreducible is wrong.")
+	}
+}
+
+func TestExtensionEigenvalues(t *testing.T) {
+	q := irreducibleQuadratic()
+	m := GenerateCompanion(Row{q[0], q[1]})
+
+	vals := m.ExtensionEigenvalues()
+	if len(vals) != 2 || len(m.Eigenvalues()) != 0 {
+		t.Fatalf("Companion matrix of irreducible quadratic had %v extension eigenvalues, not 2.", len(vals))
+	} else if vals[0].Value.Equals(vals[1].Value) {
+		t.Fatal("Extension eigenvalues aren't distinct.")
+	}
+
+	for _, val := range vals {
+		// q(value) = 0 in GF(2^8)[y]/(q).
+		sum, power := Polynomial{}, Polynomial{1}
+		for _, c := range val.Field {
+			sum = sum.Add(power.ScalarMul(c))
+			power = power.Mul(val.Value).Mod(val.Field)
+		}
+
+		if !sum.Mod(val.Field).IsZero() {
+			t.Fatalf("Extension eigenvalue %v isn't a root of %v.", val.Value, val.Field)
+		}
+	}
+
+	if len(m.Kernel(q)) != 2 {
+		t.Fatal("Kernel of the characteristic polynomial isn't everything.")
+	}
+}
+
+// irreducibleQuadratic returns an irreducible polynomial x^2 + x + c over GF(2^8).
+func irreducibleQuadratic() Polynomial {
+	for c := 1; ; c++ {
+		if q := (Polynomial{number.ByteFieldElem(c), 1, 1}); q.Roots() == nil {
+			return q
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package gfmatrix
+
+import (
+	"errors"
+)
+
+var (
+	// ErrDimensionMismatch is returned when an operation is given rows or matrices of incompatible sizes.
+	ErrDimensionMismatch = errors.New("gfmatrix: dimension mismatch")
+
+	// ErrInconsistentAssertion is returned when an assertion about a deduced matrix contradicts the previous ones.
+	ErrInconsistentAssertion = errors.New("gfmatrix: asserted input, output pair is inconsistent with previous assertions")
+
+	// ErrNoSolution is returned when a linear system is inconsistent.
+	ErrNoSolution = errors.New("gfmatrix: linear system has no solution")
+
+	// ErrMalformed is returned when a serialized matrix can't be parsed.
+	ErrMalformed = errors.New("gfmatrix: malformed serialized matrix")
+)
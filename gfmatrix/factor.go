@@ -0,0 +1,235 @@
+package gfmatrix
+
+import (
+	"github.com/OpenWhiteBox/primitives/number"
+)
+
+// RowOpOf is an elementary row operation on a vector or matrix over the field E. If Swap is true, rows Dst and Src are
+// swapped. Otherwise, if Dst == Src, row Dst is multiplied by Scalar, and if not, Scalar times row Src is added into row
+// Dst.
+type RowOpOf[E Element[E]] struct {
+	Dst, Src int
+	Scalar   E
+	Swap     bool
+}
+
+// invert returns the row operation that undoes op.
+func (op RowOpOf[E]) invert() RowOpOf[E] {
+	if !op.Swap && op.Dst == op.Src {
+		op.Scalar = op.Scalar.Invert()
+	}
+
+	return op
+}
+
+// RowOp is an elementary row operation over GF(2^8).
+type RowOp = RowOpOf[number.ByteFieldElem]
+
+// RowOpsOf is a sequence of elementary row operations, applied in order. Each one costs a field multiplication (or
+// nothing, for a swap), so a short sequence is a cheap way to evaluate or embed a linear layer, or to split one across
+// rounds.
+type RowOpsOf[E Element[E]] []RowOpOf[E]
+
+// RowOps is a sequence of elementary row operations over GF(2^8).
+type RowOps = RowOpsOf[number.ByteFieldElem]
+
+// Apply returns the result of applying the row operations to x.
+func (ops RowOpsOf[E]) Apply(x RowOf[E]) RowOf[E] {
+	out := x.Dup()
+
+	for _, op := range ops {
+		if op.Swap {
+			out[op.Dst], out[op.Src] = out[op.Src], out[op.Dst]
+		} else if op.Dst == op.Src {
+			out[op.Dst] = out[op.Dst].Mul(op.Scalar)
+		} else {
+			out[op.Dst] = out[op.Dst].Add(out[op.Src].Mul(op.Scalar))
+		}
+	}
+
+	return out
+}
+
+// Matrix returns the n-by-n matrix of the row operations, so that ops.Matrix(n).Mul(x) = ops.Apply(x).
+func (ops RowOpsOf[E]) Matrix(n int) MatrixOf[E] {
+	out := GenerateIdentityOf[E](n)
+
+	for _, op := range ops {
+		if op.Swap {
+			out[op.Dst], out[op.Src] = out[op.Src], out[op.Dst]
+		} else if op.Dst == op.Src {
+			out[op.Dst] = out[op.Dst].ScalarMul(op.Scalar)
+		} else {
+			out[op.Dst] = out[op.Dst].Add(out[op.Src].ScalarMul(op.Scalar))
+		}
+	}
+
+	return out
+}
+
+// Cost returns the number of field operations needed to apply the row operations.
+func (ops RowOpsOf[E]) Cost() (cost int) {
+	for _, op := range ops {
+		if !op.Swap {
+			cost++
+		}
+	}
+
+	return
+}
+
+// PLU returns a permutation matrix p, a lower unitriangular matrix l, and an upper triangular matrix u such that
+// e = p * l * u. It returns false if e isn't square and invertible.
+func (e MatrixOf[E]) PLU() (p, l, u MatrixOf[E], ok bool) {
+	n, m := e.Size()
+	if n != m {
+		return nil, nil, nil, false
+	}
+
+	// Row i of l * u is row perm[i] of e.
+	perm := make([]int, n)
+	for i, _ := range perm {
+		perm[i] = i
+	}
+
+	l, u = GenerateEmptyOf[E](n, n), e.Dup()
+
+	for col := 0; col < n; col++ {
+		pivot := u.FindPivot(col, col)
+		if pivot == -1 {
+			return nil, nil, nil, false
+		}
+
+		u[col], u[pivot] = u[pivot], u[col]
+		l[col], l[pivot] = l[pivot], l[col]
+		perm[col], perm[pivot] = perm[pivot], perm[col]
+
+		inv := u[col][col].Invert()
+		for i := col + 1; i < n; i++ {
+			if !u[i][col].IsZero() {
+				l[i][col] = u[i][col].Mul(inv)
+				u[i] = u[i].Add(u[col].ScalarMul(l[i][col]))
+			}
+		}
+	}
+
+	p = GenerateEmptyOf[E](n, n)
+	for i, j := range perm {
+		l[i][i] = 0x01
+		p[j][i] = 0x01
+	}
+
+	return p, l, u, true
+}
+
+// RowOps factors the matrix into a short sequence of elementary row operations, so that e.Mul(x) = ops.Apply(x). It
+// returns false if e isn't square and invertible.
+//
+// Finding the shortest sequence is hard, so this tries a greedy heuristic--repeatedly adding the multiple of one row
+// into another that zeroes the most entries--alongside plain Gauss-Jordan elimination, and returns the cheaper.
+func (e MatrixOf[E]) RowOps() (RowOpsOf[E], bool) {
+	n, m := e.Size()
+	if n != m {
+		return nil, false
+	} else if _, ok := e.Invert(); !ok {
+		return nil, false
+	}
+
+	greedy := reduceGreedy(e.Dup(), nil)
+	plain := reduceElimination(e.Dup(), nil)
+
+	if plain.Cost() < greedy.Cost() || plain.Cost() == greedy.Cost() && len(plain) < len(greedy) {
+		greedy = plain
+	}
+
+	// The operations reduce e to the identity, so e is the product of their inverses in reverse order.
+	out := make(RowOpsOf[E], len(greedy))
+	for i, op := range greedy {
+		out[len(out)-1-i] = op.invert()
+	}
+
+	return out, true
+}
+
+// reduceGreedy reduces f towards the identity by adding whichever multiple of one row into another lowers the number
+// of non-zero entries of f the most, and then finishes with Gauss-Jordan elimination once that's no longer possible. It
+// appends the operations used to ops.
+func reduceGreedy[E Element[E]](f MatrixOf[E], ops RowOpsOf[E]) RowOpsOf[E] {
+	weights := make([]int, len(f))
+	for i, row := range f {
+		weights[i] = rowWeight(row)
+	}
+
+	for {
+		best, dst, src, scalar := 0, -1, -1, E(0)
+
+		for i, a := range f {
+			for j, b := range f {
+				if i == j {
+					continue
+				}
+
+				// Only multiples that cancel some entry of row i can lower its weight.
+				for k, b_k := range b {
+					if a[k].IsZero() || b_k.IsZero() {
+						continue
+					}
+
+					c := a[k].Mul(b_k.Invert())
+					if gain := weights[i] - rowWeight(a.Add(b.ScalarMul(c))); gain > best {
+						best, dst, src, scalar = gain, i, j, c
+					}
+				}
+			}
+		}
+
+		if dst == -1 {
+			return reduceElimination(f, ops)
+		}
+
+		f[dst] = f[dst].Add(f[src].ScalarMul(scalar))
+		weights[dst] -= best
+		ops = append(ops, RowOpOf[E]{Dst: dst, Src: src, Scalar: scalar})
+	}
+}
+
+// reduceElimination reduces f to the identity with Gauss-Jordan elimination, appending the operations used to ops.
+func reduceElimination[E Element[E]](f MatrixOf[E], ops RowOpsOf[E]) RowOpsOf[E] {
+	for col, _ := range f {
+		pivot := f.FindPivot(col, col)
+
+		if pivot != col {
+			f[col], f[pivot] = f[pivot], f[col]
+			ops = append(ops, RowOpOf[E]{Dst: col, Src: pivot, Swap: true})
+		}
+
+		inv := f[col][col].Invert()
+		for i, row := range f {
+			if i != col && !row[col].IsZero() {
+				c := row[col].Mul(inv)
+				f[i] = row.Add(f[col].ScalarMul(c))
+				ops = append(ops, RowOpOf[E]{Dst: i, Src: col, Scalar: c})
+			}
+		}
+	}
+
+	for i, row := range f {
+		if !row[i].IsOne() {
+			ops = append(ops, RowOpOf[E]{Dst: i, Src: i, Scalar: row[i].Invert()})
+			f[i] = row.ScalarMul(row[i].Invert())
+		}
+	}
+
+	return ops
+}
+
+// rowWeight returns the number of non-zero entries in a row.
+func rowWeight[E Element[E]](row RowOf[E]) (w int) {
+	for _, x := range row {
+		if !x.IsZero() {
+			w++
+		}
+	}
+
+	return
+}
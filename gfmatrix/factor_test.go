@@ -0,0 +1,63 @@
+package gfmatrix
+
+import (
+	"testing"
+
+	"crypto/rand"
+)
+
+func TestPLU(t *testing.T) {
+	for _, m := range []Matrix{mixColumns, randomInvertible(16)} {
+		n, _ := m.Size()
+
+		p, l, u, ok := m.PLU()
+		if !ok {
+			t.Fatalf("PLU failed on invertible %v-by-%v matrix.", n, n)
+		} else if !p.Compose(l).Compose(u).Equals(m) {
+			t.Fatalf("p * l * u isn't the original %v-by-%v matrix.", n, n)
+		} else if !p.IsBinary() {
+			t.Fatalf("p isn't a permutation matrix.")
+		}
+
+		for i := 0; i < n; i++ {
+			if !l[i][i].IsOne() || u[i][i].IsZero() {
+				t.Fatalf("PLU factors of %v-by-%v matrix have the wrong diagonal.", n, n)
+			}
+
+			for j := i + 1; j < n; j++ {
+				if !l[i][j].IsZero() || !u[j][i].IsZero() {
+					t.Fatalf("PLU factors of %v-by-%v matrix aren't triangular.", n, n)
+				}
+			}
+		}
+	}
+
+	if _, _, _, ok := GenerateEmpty(4, 4).PLU(); ok {
+		t.Fatalf("PLU succeeded on singular matrix.")
+	}
+}
+
+func TestRowOps(t *testing.T) {
+	for _, m := range []Matrix{mixColumns, randomInvertible(16)} {
+		n, _ := m.Size()
+
+		ops, ok := m.RowOps()
+		if !ok {
+			t.Fatalf("RowOps failed on invertible %v-by-%v matrix.", n, n)
+		} else if !ops.Matrix(n).Equals(m) {
+			t.Fatalf("Row operations don't multiply out to the original %v-by-%v matrix.", n, n)
+		}
+
+		x := GenerateRandomRow(rand.Reader, n)
+		if !ops.Apply(x).Equals(m.Mul(x)) {
+			t.Fatalf("Applying row operations doesn't match multiplying by the %v-by-%v matrix.", n, n)
+		}
+
+		t.Logf("%v-by-%v: %v operations", n, n, ops.Cost())
+	}
+}
+
+func randomInvertible(n int) Matrix {
+	m, _ := GenerateRandom(rand.Reader, n)
+	return m
+}
@@ -2,13 +2,10 @@ package gfmatrix
 
 // gaussJordan reduces the matrix according to the Gauss-Jordan Method.  Returns the augment matrix, the transformed
 // matrix, and the set set of free variables.
-func (e Matrix) gaussJordan() (aug, f Matrix, frees []int) {
+func (e MatrixOf[E]) gaussJordan() (aug, f MatrixOf[E], frees []int) {
 	out, in := e.Size()
 
-	aug = GenerateIdentity(in)
-	for x := in; x < out; x++ {
-		aug = append(aug, NewRow(in))
-	}
+	aug = GenerateIdentityOf[E](out)
 
 	f = e.Dup() // Duplicate e away so we don't mutate it.
 
@@ -47,27 +44,27 @@ func (e Matrix) gaussJordan() (aug, f Matrix, frees []int) {
 	}
 
 	// Add the rest of the free variables for completion.
-	for free := out; free < in; free++ {
-		frees = append(frees, free)
+	for ; col < in; col++ {
+		frees = append(frees, col)
 	}
 
 	return
 }
 
 // NullSpace returns a basis for the matrix's nullspace.
-func (e Matrix) NullSpace() (basis []Row) {
+func (e MatrixOf[E]) NullSpace() (basis []RowOf[E]) {
 	out, in := e.Size()
 	if out == 0 {
-		return []Row{}
+		return []RowOf[E]{}
 	}
 
 	_, f, frees := e.gaussJordan()
 
 	for _, free := range frees {
-		input := NewRow(in)
+		input := NewRowOf[E](in)
 		input[free] = 0x01
 
-		for _, row := range f[:in] {
+		for _, row := range f {
 			if !row[free].IsZero() {
 				input[row.Height()] = row[free]
 			}
@@ -8,10 +8,15 @@ import (
 
 // GenerateEmpty generates the n-by-m matrix with all entries set to 0.
 func GenerateEmpty(n, m int) Matrix {
-	out := make([]Row, n)
+	return GenerateEmptyOf[number.ByteFieldElem](n, m)
+}
+
+// GenerateEmptyOf generates the n-by-m matrix over the field E with all entries set to 0.
+func GenerateEmptyOf[E Element[E]](n, m int) MatrixOf[E] {
+	out := make([]RowOf[E], n)
 
 	for i := 0; i < n; i++ {
-		out[i] = NewRow(m)
+		out[i] = NewRowOf[E](m)
 	}
 
 	return out
@@ -19,7 +24,12 @@ func GenerateEmpty(n, m int) Matrix {
 
 // GenerateIdentity generates the n-by-n identity matrix.
 func GenerateIdentity(n int) Matrix {
-	out := GenerateEmpty(n, n)
+	return GenerateIdentityOf[number.ByteFieldElem](n)
+}
+
+// GenerateIdentityOf generates the n-by-n identity matrix over the field E.
+func GenerateIdentityOf[E Element[E]](n int) MatrixOf[E] {
+	out := GenerateEmptyOf[E](n, n)
 
 	for i, _ := range out {
 		out[i][i] = 0x01
@@ -30,7 +40,13 @@ func GenerateIdentity(n int) Matrix {
 
 // GenerateRandomBinaryRow generates a random n-component row containing only 1s and 0s, using the random source reader.
 func GenerateRandomBinaryRow(reader io.Reader, n int) Row {
-	out := GenerateRandomRow(reader, n)
+	return GenerateRandomBinaryRowOf[number.ByteFieldElem](reader, n)
+}
+
+// GenerateRandomBinaryRowOf generates a random n-component row over the field E containing only 1s and 0s, using the
+// random source reader.
+func GenerateRandomBinaryRowOf[E Element[E]](reader io.Reader, n int) RowOf[E] {
+	out := GenerateRandomRowOf[E](reader, n)
 
 	for i, v := range out {
 		out[i] = v & 1
@@ -41,37 +57,106 @@ func GenerateRandomBinaryRow(reader io.Reader, n int) Row {
 
 // GenerateRandomRow generates a random n-component row using the random source reader.
 func GenerateRandomRow(reader io.Reader, n int) Row {
-	out, temp := NewRow(n), make([]byte, n)
-	reader.Read(temp)
+	return GenerateRandomRowOf[number.ByteFieldElem](reader, n)
+}
 
+// GenerateRandomRowOf generates a random n-component row over the field E using the random source reader. Each entry
+// is read as one byte, and bits past the field's degree are dropped.
+func GenerateRandomRowOf[E Element[E]](reader io.Reader, n int) RowOf[E] {
+	out, temp := NewRowOf[E](n), make([]byte, n)
+	io.ReadFull(reader, temp)
+
+	mask := fieldSize[E]() - 1
 	for i, v := range temp {
-		out[i] = number.ByteFieldElem(v)
+		out[i] = E(int(v) & mask)
 	}
 
-	return Row(out)
+	return out
 }
 
 // GenerateTrueRandom generates a random n-by-n matrix (not guaranteed to be invertible) using the random source reader
 // (for example, crypto/rand.Reader).
 func GenerateTrueRandom(reader io.Reader, n int) Matrix {
-	m := make([]Row, n)
+	return GenerateTrueRandomOf[number.ByteFieldElem](reader, n)
+}
+
+// GenerateTrueRandomOf generates a random n-by-n matrix over the field E (not guaranteed to be invertible) using the
+// random source reader.
+func GenerateTrueRandomOf[E Element[E]](reader io.Reader, n int) MatrixOf[E] {
+	m := make([]RowOf[E], n)
 
 	for i := 0; i < n; i++ { // Generate random n x n matrix.
-		m[i] = GenerateRandomRow(reader, n)
+		m[i] = GenerateRandomRowOf[E](reader, n)
 	}
 
 	return m
 }
 
-// GenerateRandom generates a random invertible n-by-n matrix using the random source reader (for example,
+// generateRandomTail generates a random n-component row which is zero before position i.
+func generateRandomTail[E Element[E]](reader io.Reader, n, i int) RowOf[E] {
+	out := GenerateRandomRowOf[E](reader, n)
+
+	for j := 0; j < i; j++ {
+		out[j] = 0x00
+	}
+
+	return out
+}
+
+// GenerateRandom generates a uniformly random invertible n-by-n matrix using the random source reader (for example,
 // crypto/rand.Reader). Returns it and its inverse.
+//
+// A uniformly random invertible matrix M has a uniformly random non-zero first column c. If E is a fixed invertible
+// matrix with first column c, then M = E * [[1, w], [0, M']] where w is a uniformly random row and M' is a uniformly
+// random invertible matrix one size smaller. M is built by unrolling this from the bottom-right corner up, which is a
+// random PLU decomposition. Nothing is ever rejected except a zero vector when a non-zero c is needed, and the inverse
+// is tracked with row operations along the way.
 func GenerateRandom(reader io.Reader, n int) (Matrix, Matrix) {
-	m := GenerateTrueRandom(reader, n)
+	return GenerateRandomOf[number.ByteFieldElem](reader, n)
+}
 
-	mInv, ok := m.Invert()
-	if !ok { // Try again
-		return GenerateRandom(reader, n) // Performance bottleneck.
+// GenerateRandomOf generates a uniformly random invertible n-by-n matrix over the field E using the random source
+// reader, and its inverse. See GenerateRandom.
+func GenerateRandomOf[E Element[E]](reader io.Reader, n int) (MatrixOf[E], MatrixOf[E]) {
+	// m holds M and inv holds the transpose of M's inverse, so that both are updated with row operations.
+	m, inv := GenerateEmptyOf[E](n, n), GenerateEmptyOf[E](n, n)
+
+	for i := n - 1; i >= 0; i-- {
+		c := generateRandomTail[E](reader, n, i)
+		for c.IsZero() {
+			c = generateRandomTail[E](reader, n, i)
+		}
+		w := generateRandomTail[E](reader, n, i+1)
+
+		// B = [[1, w], [0, M']], so B^-1 = [[1, -w M'^-1], [0, M'^-1]].
+		m[i] = w
+		m[i][i] = 0x01
+
+		for r := i + 1; r < n; r++ {
+			inv[r][i] = inv[r].DotProduct(w)
+		}
+		inv[i][i] = 0x01
+
+		// E's first column is c and its other columns are the unit vectors except e_k, where k is c's first non-zero
+		// entry. Left-multiplying B by E moves B's first row to position k, scales it by c_k, and adds c_t times it to
+		// every other row t. Left-multiplying the transposed inverse by the transpose of E^-1 moves its first row to
+		// position k, adds c_t times every other row t to it, and scales it by c_k^-1.
+		k := c.Height()
+
+		b, nb := m[i], inv[i]
+		copy(m[i:k], m[i+1:k+1])
+		copy(inv[i:k], inv[i+1:k+1])
+		m[k], inv[k] = b.ScalarMul(c[k]), nb
+
+		for t := i; t < n; t++ {
+			if t != k && !c[t].IsZero() {
+				m[t] = m[t].Add(b.ScalarMul(c[t]))
+				inv[k] = inv[k].Add(inv[t].ScalarMul(c[t]))
+			}
+		}
+
+		inv[k] = inv[k].ScalarMul(c[k].Invert())
 	}
 
-	return m, mInv // This one works!
+	return m, inv.Transpose()
 }
@@ -1,33 +1,62 @@
 // Package gfmatrix implements basic operations on matrices over Rijndael's field and the random generation of new ones.
+//
+// Matrix and Row are over Rijndael's field. MatrixOf and RowOf are the same over any binary field of degree up to 8,
+// like SM4's field or GF(2^4), and everything that works on one works on the other:
+//
+//	m, _ := gfmatrix.GenerateRandomOf[number.FieldElem[number.SM4]](rand.Reader, 4)
+//
+// Functions that take a random source read all of their randomness from it, so a seeded reader (for example,
+// math/rand.New(math/rand.NewSource(seed))) makes them deterministic.
 package gfmatrix
 
 import (
 	"fmt"
+	"regexp"
+
+	"github.com/OpenWhiteBox/primitives/number"
 )
 
+// packagePath matches the directories of a package path, like "github.com/OpenWhiteBox/primitives/", which %T includes in
+// type arguments but Go source doesn't.
+var packagePath = regexp.MustCompile(`([\w.-]+/)+`)
+
+// MatrixOf represents a matrix over the field E.
+type MatrixOf[E Element[E]] []RowOf[E]
+
 // Matrix represents a GF(2^8)-matrix.
-type Matrix []Row
+type Matrix = MatrixOf[number.ByteFieldElem]
 
 // Mul right-multiplies a matrix by a row.
-func (e Matrix) Mul(f Row) Row {
+func (e MatrixOf[E]) Mul(f RowOf[E]) RowOf[E] {
+	res, err := e.CheckedMul(f)
+	if err != nil {
+		panic("Can't multiply by row that is wrong size!")
+	}
+
+	return res
+}
+
+// CheckedMul right-multiplies a matrix by a row. It returns ErrDimensionMismatch instead of panicking if the row is the
+// wrong size.
+func (e MatrixOf[E]) CheckedMul(f RowOf[E]) (RowOf[E], error) {
 	out, in := e.Size()
 	if in != f.Size() {
-		panic("Can't multiply by row that is wrong size!")
+		return nil, ErrDimensionMismatch
 	}
 
-	res := NewRow(out)
+	res := NewRowOf[E](out)
 	for i := 0; i < out; i++ {
 		res[i] = e[i].DotProduct(f)
 	}
 
-	return res
+	return res, nil
 }
 
-// Add adds two matrices from GF(2^8)^nxm.
-func (e Matrix) Add(f Matrix) Matrix {
+// Add adds two matrices from GF(2^k)^nxm.
+func (e MatrixOf[E]) Add(f MatrixOf[E]) MatrixOf[E] {
 	a, _ := e.Size()
 
-	out := make([]Row, a)
+	out := make([]RowOf[E], a)
 	for i, _ := range out {
 		out[i] = e[i].Add(f[i])
 	}
@@ -36,15 +65,26 @@ func (e Matrix) Add(f Matrix) Matrix {
 }
 
 // Compose returns the result of composing e with f.
-func (e Matrix) Compose(f Matrix) Matrix {
+func (e MatrixOf[E]) Compose(f MatrixOf[E]) MatrixOf[E] {
+	out, err := e.CheckedCompose(f)
+	if err != nil {
+		panic("Can't multiply matrices of the wrong size!")
+	}
+
+	return out
+}
+
+// CheckedCompose returns the result of composing e with f. It returns ErrDimensionMismatch instead of panicking if the
+// matrices are incompatible sizes.
+func (e MatrixOf[E]) CheckedCompose(f MatrixOf[E]) (MatrixOf[E], error) {
 	n, m := e.Size()
 	p, q := f.Size()
 
 	if m != p {
-		panic("Can't multiply matrices of the wrong size!")
+		return nil, ErrDimensionMismatch
 	}
 
-	out := GenerateEmpty(n, q)
+	out := GenerateEmptyOf[E](n, q)
 	g := f.Transpose()
 
 	for i, e_i := range e {
@@ -53,13 +93,13 @@ func (e Matrix) Compose(f Matrix) Matrix {
 		}
 	}
 
-	return out
+	return out, nil
 }
 
 // Transpose returns the transpose of a matrix.
-func (e Matrix) Transpose() Matrix {
+func (e MatrixOf[E]) Transpose() MatrixOf[E] {
 	n, m := e.Size()
-	out := GenerateEmpty(m, n)
+	out := GenerateEmptyOf[E](m, n)
 
 	for i, row := range e {
 		for j, elem := range row {
@@ -71,14 +111,14 @@ func (e Matrix) Transpose() Matrix {
 }
 
 // Invert computes the multiplicative inverse of a matrix, if it exists.
-func (e Matrix) Invert() (Matrix, bool) {
+func (e MatrixOf[E]) Invert() (MatrixOf[E], bool) {
 	inv, _, frees := e.gaussJordan()
 	return inv, len(frees) == 0
 }
 
 // FindPivot finds a row with non-zero entry in column col, starting at the given row and moving down. It returns the
 // index of the row or -1 if one does not exist.
-func (e Matrix) FindPivot(row, col int) int {
+func (e MatrixOf[E]) FindPivot(row, col int) int {
 	out, _ := e.Size()
 
 	for i := row; i < out; i++ {
@@ -91,9 +131,9 @@ func (e Matrix) FindPivot(row, col int) int {
 }
 
 // Dup returns a duplicate of this matrix.
-func (e Matrix) Dup() Matrix {
+func (e MatrixOf[E]) Dup() MatrixOf[E] {
 	n, m := e.Size()
-	out := GenerateEmpty(n, m)
+	out := GenerateEmptyOf[E](n, m)
 
 	for i, row := range e {
 		for j, elem := range row {
@@ -105,7 +145,7 @@ func (e Matrix) Dup() Matrix {
 }
 
 // IsBinary returns true if the matrix contains only zero and one entries.
-func (e Matrix) IsBinary() bool {
+func (e MatrixOf[E]) IsBinary() bool {
 	for _, row := range e {
 		for _, col := range row {
 			if !col.IsZero() && !col.IsOne() {
@@ -118,7 +158,7 @@ func (e Matrix) IsBinary() bool {
 }
 
 // Equals returns true if two matrices are equal and false otherwise.
-func (e Matrix) Equals(f Matrix) bool {
+func (e MatrixOf[E]) Equals(f MatrixOf[E]) bool {
 	a, _ := e.Size()
 	b, _ := f.Size()
 
@@ -136,7 +176,7 @@ func (e Matrix) Equals(f Matrix) bool {
 }
 
 // Size returns the dimensions of the matrix in (Rows, Columns) order.
-func (e Matrix) Size() (int, int) {
+func (e MatrixOf[E]) Size() (int, int) {
 	if len(e) == 0 {
 		return 0, 0
 	} else {
@@ -144,7 +184,7 @@ func (e Matrix) Size() (int, int) {
 	}
 }
 
-func (e Matrix) String() string {
+func (e MatrixOf[E]) String() string {
 	out := []rune{}
 
 	for _, row := range e {
@@ -156,7 +196,7 @@ func (e Matrix) String() string {
 }
 
 // OctaveString converts the matrix into a string that can be imported into Octave.
-func (e Matrix) OctaveString() string {
+func (e MatrixOf[E]) OctaveString() string {
 	out := []rune{}
 
 	for _, row := range e {
@@ -166,11 +206,19 @@ func (e Matrix) OctaveString() string {
 	return string(out)
 }
 
-func (e Matrix) GoString() string {
-	out := []rune("gfmatrix.Matrix{\n")
+// GoString converts the matrix into a Go composite literal. It's a Matrix literal over Rijndael's field, and a MatrixOf
+// literal naming the field's type otherwise, so that it compiles to a matrix over the same field.
+func (e MatrixOf[E]) GoString() string {
+	matrixType, rowType := "gfmatrix.Matrix", "gfmatrix.Row"
+	if _, ok := any(E(0)).(number.ByteFieldElem); !ok {
+		elem := packagePath.ReplaceAllString(fmt.Sprintf("%T", E(0)), "")
+		matrixType, rowType = "gfmatrix.MatrixOf["+elem+"]", "gfmatrix.RowOf["+elem+"]"
+	}
+
+	out := []rune(matrixType + "{\n")
 
 	for _, row := range e {
-		out = append(out, []rune("\tgfmatrix.Row{")...)
+		out = append(out, []rune("\t"+rowType+"{")...)
 
 		for _, elem := range row[:len(row)-1] {
 			out = append(out, []rune(fmt.Sprintf("0x%2.2x, ", elem))...)
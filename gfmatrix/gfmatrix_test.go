@@ -1,9 +1,13 @@
 package gfmatrix
 
 import (
+	"strings"
 	"testing"
 
 	"crypto/rand"
+	mrand "math/rand"
+
+	"github.com/OpenWhiteBox/primitives/number"
 )
 
 func TestNullSpace(t *testing.T) {
@@ -28,6 +32,39 @@ func TestNullSpace(t *testing.T) {
 	}
 }
 
+func TestNullSpaceShapes(t *testing.T) {
+	wide := GenerateEmpty(3, 6)
+	for i, _ := range wide {
+		wide[i] = GenerateRandomRow(rand.Reader, 6)
+		wide[i][1] = 0x00 // Column 1 has no pivot, so it's free before any pivots are found.
+	}
+
+	tall := GenerateEmpty(6, 4)
+	for i, _ := range tall {
+		tall[i] = GenerateRandomRow(rand.Reader, 4)
+		tall[i][0] = 0x00
+		tall[i][3] = tall[i][2].Mul(0x05) // Column 3 depends on column 2, so it's free after every pivot is found.
+	}
+
+	for _, c := range []struct {
+		name  string
+		m     Matrix
+		nulls int
+	}{{"wide", wide, 3}, {"tall", tall, 2}} {
+		basis := c.m.NullSpace()
+
+		if len(basis) != c.nulls || Matrix(basis).Rank() != c.nulls {
+			t.Fatalf("NullSpace of %v matrix had dimension %v, not %v.", c.name, Matrix(basis).Rank(), c.nulls)
+		}
+
+		for _, x := range basis {
+			if !c.m.Mul(x).IsZero() {
+				t.Fatalf("NullSpace of %v matrix contains a vector that isn't in the null space.", c.name)
+			}
+		}
+	}
+}
+
 func TestInvert(t *testing.T) {
 	m := Matrix{
 		Row{0x01, 0x00, 0x00, 0x00},
@@ -76,3 +113,88 @@ func TestStretch(t *testing.T) {
 		t.Fatal("LeftStretch is wrong!")
 	}
 }
+
+func TestCheckedDimensionMismatch(t *testing.T) {
+	m, _ := GenerateRandom(rand.Reader, 16)
+
+	if _, err := NewRow(16).CheckedAdd(NewRow(8)); err != ErrDimensionMismatch {
+		t.Fatalf("CheckedAdd didn't report dimension mismatch: %v", err)
+	} else if _, err := m.CheckedMul(NewRow(8)); err != ErrDimensionMismatch {
+		t.Fatalf("CheckedMul didn't report dimension mismatch: %v", err)
+	} else if _, err := m.CheckedCompose(GenerateIdentity(8)); err != ErrDimensionMismatch {
+		t.Fatalf("CheckedCompose didn't report dimension mismatch: %v", err)
+	}
+}
+
+func TestGenerateRandom(t *testing.T) {
+	m, mInv := GenerateRandom(rand.Reader, 16)
+
+	if !m.Compose(mInv).Equals(GenerateIdentity(16)) || !mInv.Compose(m).Equals(GenerateIdentity(16)) {
+		t.Fatal("M * M^-1 != M^-1 * M != I")
+	}
+
+	// The same seed should give the same matrix.
+	x, _ := GenerateRandom(mrand.New(mrand.NewSource(1)), 16)
+	y, _ := GenerateRandom(mrand.New(mrand.NewSource(1)), 16)
+
+	if !x.Equals(y) {
+		t.Fatal("GenerateRandom gave different matrices for the same seed.")
+	}
+}
+
+func TestOtherFields(t *testing.T) {
+	testOtherField[number.FieldElem[number.SM4]](t, 8)
+	testOtherField[number.FieldElem[number.Nibble]](t, 8)
+}
+
+func testOtherField[E Element[E]](t *testing.T, n int) {
+	m, mInv := GenerateRandomOf[E](rand.Reader, n)
+
+	if !m.Compose(mInv).Equals(GenerateIdentityOf[E](n)) {
+		t.Fatalf("GenerateRandomOf returned the wrong inverse over %T!", E(0))
+	}
+
+	if inv, ok := m.Invert(); !ok || !inv.Equals(mInv) {
+		t.Fatalf("Invert failed over %T!", E(0))
+	}
+
+	for _, row := range m {
+		for _, elem := range row {
+			if int(elem) >= fieldSize[E]() {
+				t.Fatalf("GenerateRandomOf returned an entry outside of %T!", E(0))
+			}
+		}
+	}
+
+	raw, _ := m.MarshalBinary()
+	parsed := MatrixOf[E]{}
+	if err := parsed.UnmarshalBinary(raw); err != nil || !parsed.Equals(m) {
+		t.Fatalf("Marshaling round trip failed over %T!", E(0))
+	}
+}
+
+func TestOtherFieldBranchNumber(t *testing.T) {
+	// The MixColumns matrix is MDS over GF(2^4) too.
+	m := MatrixOf[number.FieldElem[number.Nibble]]{
+		{2, 3, 1, 1},
+		{1, 2, 3, 1},
+		{1, 1, 2, 3},
+		{3, 1, 1, 2},
+	}
+
+	if !m.IsMDS() {
+		t.Fatal("IsMDS says matrix isn't MDS.")
+	} else if b := m.DifferentialBranchNumber(BranchOptions{}); b != 5 {
+		t.Fatalf("DifferentialBranchNumber = %v, not 5", b)
+	}
+
+	if s := m.SageString(); !strings.HasPrefix(s, "matrix(GF(2^4, 'a', modulus=x^4+x+1), [") {
+		t.Fatalf("SageString has the wrong field: %v", s)
+	}
+
+	if s := m.GoString(); !strings.HasPrefix(s, "gfmatrix.MatrixOf[number.FieldElem[number.Nibble]]{\n\tgfmatrix.RowOf[number.FieldElem[number.Nibble]]{0x02,") {
+		t.Fatalf("GoString has the wrong type: %v", s)
+	} else if _, err := ParseGoString(s); err == nil {
+		t.Fatal("ParseGoString parsed a matrix over GF(2^4) as one over Rijndael's field.")
+	}
+}
@@ -2,40 +2,76 @@ package gfmatrix
 
 import (
 	"crypto/rand"
+	"io"
+	"math/big"
 	"sort"
+
+	"github.com/OpenWhiteBox/primitives/number"
 )
 
-// IncrementalMatrix is an invertible matrix that can be generated incrementally. Implements sort.Interface.
+// IncrementalMatrixOf is an invertible matrix over the field E that can be generated incrementally. Implements
+// sort.Interface.
 //
 // For example, in cryptanalyses, we might be able to do some work and discover some rows of a matrix. We want to stop
 // working as soon as its fully defined, but we also can't just work until we have n rows because we might have
 // recovered duplicate or linearly dependent rows.
-type IncrementalMatrix struct {
-	n        int    // The dimension of the matrix.
-	raw      Matrix // The collection of rows as they were put in.
-	simplest Matrix // The matrix in Gauss-Jordan eliminated form.
-	inverse  Matrix // The inverse matrix of raw.
+type IncrementalMatrixOf[E Element[E]] struct {
+	n        int         // The dimension of the matrix.
+	raw      MatrixOf[E] // The collection of rows as they were put in.
+	simplest MatrixOf[E] // The matrix in Gauss-Jordan eliminated form.
+	inverse  MatrixOf[E] // The inverse matrix of raw.
+
+	log   []undo[E] // Every change made since the first outstanding checkpoint, so that it can be undone.
+	depth int       // The number of outstanding checkpoints. Changes are only logged while it's positive.
 }
 
+// Marker identifies a point in the history of an incremental matrix that it can be rolled back to.
+type Marker struct {
+	length, depth int // The length of the undo log, and the number of outstanding checkpoints including this one.
+}
+
+// undo is an entry in an incremental matrix's undo log.
+type undo[E Element[E]] struct {
+	kind              undoKind
+	i, j              int
+	simplest, inverse RowOf[E]
+}
+
+type undoKind int
+
+const (
+	undoAppend    undoKind = iota // A row was appended.
+	undoOverwrite                 // Row i of simplest and inverse was overwritten; the old rows are saved.
+	undoSwap                      // Rows i and j of simplest and inverse were swapped.
+)
+
+// IncrementalMatrix is an incremental matrix over GF(2^8).
+type IncrementalMatrix = IncrementalMatrixOf[number.ByteFieldElem]
+
 // NewIncrementalMatrix initializes a new n-by-n incremental matrix.
 func NewIncrementalMatrix(n int) IncrementalMatrix {
-	return IncrementalMatrix{
+	return NewIncrementalMatrixOf[number.ByteFieldElem](n)
+}
+
+// NewIncrementalMatrixOf initializes a new n-by-n incremental matrix over the field E.
+func NewIncrementalMatrixOf[E Element[E]](n int) IncrementalMatrixOf[E] {
+	return IncrementalMatrixOf[E]{
 		n:        n,
-		raw:      Matrix{},
-		simplest: Matrix{},
-		inverse:  Matrix{},
+		raw:      MatrixOf[E]{},
+		simplest: MatrixOf[E]{},
+		inverse:  MatrixOf[E]{},
 	}
 }
 
 // reduce takes an arbitrary row as input and reduces it according to the Gauss-Jordan method with the current matrix.
 // It returns the reduced row and the corresponding row in the inverse matrix.
-func (im *IncrementalMatrix) reduce(raw Row) (Row, Row) {
+func (im *IncrementalMatrixOf[E]) reduce(raw RowOf[E]) (RowOf[E], RowOf[E]) {
 	if raw.Size() != im.n {
 		panic("Tried to reduce incorrectly sized row with incremental matrix!")
 	}
 
 	reduced := raw.Dup()
-	inverse := NewRow(im.n)
+	inverse := NewRowOf[E](im.n)
 	if len(im.raw) < im.n {
 		inverse[len(im.raw)] = 0x01
 	}
@@ -54,7 +90,7 @@ func (im *IncrementalMatrix) reduce(raw Row) (Row, Row) {
 }
 
 // addRows adds each row to their respective matrices and puts im.simplest back in simplest form.
-func (im *IncrementalMatrix) addRows(raw, reduced, inverse Row) {
+func (im *IncrementalMatrixOf[E]) addRows(raw, reduced, inverse RowOf[E]) {
 	height := reduced.Height()
 
 	correction := reduced[height].Invert()
@@ -64,6 +100,8 @@ func (im *IncrementalMatrix) addRows(raw, reduced, inverse Row) {
 	// Cancel every other row in the simplest form with cand.
 	for i, _ := range im.simplest {
 		if !im.simplest[i][height].IsZero() {
+			im.record(undo[E]{kind: undoOverwrite, i: i, simplest: im.simplest[i], inverse: im.inverse[i]})
+
 			correction := im.simplest[i][height]
 			im.simplest[i] = im.simplest[i].Add(reduced.ScalarMul(correction))
 			im.inverse[i] = im.inverse[i].Add(inverse.ScalarMul(correction))
@@ -73,11 +111,74 @@ func (im *IncrementalMatrix) addRows(raw, reduced, inverse Row) {
 	im.raw = append(im.raw, raw.Dup())
 	im.simplest = append(im.simplest, reduced.Dup())
 	im.inverse = append(im.inverse, inverse.Dup())
+
+	im.record(undo[E]{kind: undoAppend})
+}
+
+// record adds an entry to the undo log, if there's a checkpoint that it could be rolled back to.
+func (im *IncrementalMatrixOf[E]) record(entry undo[E]) {
+	if im.depth > 0 {
+		im.log = append(im.log, entry)
+	}
+}
+
+// Checkpoint returns a marker for the current state of the matrix, which can be passed to Rollback to undo every change
+// made after it, or to Commit to keep them. This is much cheaper than Dup in backtracking searches, which can mutate one
+// matrix in place. Changes are only logged while a checkpoint is outstanding, so every checkpoint should eventually be
+// rolled back or committed.
+func (im *IncrementalMatrixOf[E]) Checkpoint() Marker {
+	im.depth++
+	return Marker{len(im.log), im.depth}
+}
+
+// Rollback undoes every change made to the matrix since the given marker was returned by Checkpoint. The marker and
+// every one returned after it are invalid afterwards.
+func (im *IncrementalMatrixOf[E]) Rollback(marker Marker) {
+	im.checkMarker(marker)
+
+	for k := len(im.log) - 1; k >= marker.length; k-- {
+		entry := im.log[k]
+
+		switch entry.kind {
+		case undoAppend:
+			last := len(im.raw) - 1
+			im.raw, im.simplest, im.inverse = im.raw[:last], im.simplest[:last], im.inverse[:last]
+		case undoOverwrite:
+			im.simplest[entry.i], im.inverse[entry.i] = entry.simplest, entry.inverse
+		case undoSwap:
+			im.simplest[entry.i], im.simplest[entry.j] = im.simplest[entry.j], im.simplest[entry.i]
+			im.inverse[entry.i], im.inverse[entry.j] = im.inverse[entry.j], im.inverse[entry.i]
+		}
+	}
+
+	im.log = im.log[:marker.length]
+	im.release(marker)
+}
+
+// Commit keeps every change made to the matrix since the given marker was returned by Checkpoint. The marker and every
+// one returned after it are invalid afterwards, but earlier ones can still roll the changes back.
+func (im *IncrementalMatrixOf[E]) Commit(marker Marker) {
+	im.checkMarker(marker)
+	im.release(marker)
+}
+
+// checkMarker panics if the marker isn't outstanding.
+func (im *IncrementalMatrixOf[E]) checkMarker(marker Marker) {
+	if marker.depth < 1 || marker.depth > im.depth || marker.length > len(im.log) {
+		panic("Can't use a marker that's invalid!")
+	}
+}
+
+// release forgets the given checkpoint and every one after it. Once none are left, the undo log is dropped.
+func (im *IncrementalMatrixOf[E]) release(marker Marker) {
+	if im.depth = marker.depth - 1; im.depth == 0 {
+		im.log = nil
+	}
 }
 
 // Add tries to add the row to the matrix. It mutates nothing if the new row would make the matrix singular. Add returns
 // success or failure.
-func (im *IncrementalMatrix) Add(raw Row) bool {
+func (im *IncrementalMatrixOf[E]) Add(raw RowOf[E]) bool {
 	reduced, inverse := im.reduce(raw)
 
 	if reduced.IsZero() {
@@ -89,24 +190,104 @@ func (im *IncrementalMatrix) Add(raw Row) bool {
 }
 
 // FullyDefined returns true if the matrix has been fully defined and false if it hasn't.
-func (im *IncrementalMatrix) FullyDefined() bool {
+func (im *IncrementalMatrixOf[E]) FullyDefined() bool {
 	return im.n == len(im.raw)
 }
 
 // IsInSpan returns whether not not the given row can be expressed as a linear combination of currently known rows.
-func (im *IncrementalMatrix) IsInSpan(in Row) bool {
+func (im *IncrementalMatrixOf[E]) IsInSpan(in RowOf[E]) bool {
 	reduced, _ := im.reduce(in)
 	return reduced.IsZero()
 }
 
+// Size returns the number of rows that are a linear combination of the known rows of the matrix. It's q^k, where q is
+// the size of the field and k is the number of known rows, so it's a big.Int.
+func (im *IncrementalMatrixOf[E]) Size() *big.Int {
+	return power(fieldSize[E](), len(im.raw))
+}
+
+// Row returns the nth row that is a linear combination of the known rows of the matrix: the digits of n in base
+// 2^k, where the field is GF(2^k), are the coefficients of each known row. n will be considered modulo im.Size().
+func (im *IncrementalMatrixOf[E]) Row(n *big.Int) RowOf[E] {
+	q := big.NewInt(int64(fieldSize[E]()))
+	rest, digit := new(big.Int).Mod(n, im.Size()), new(big.Int)
+
+	out := NewRowOf[E](im.n)
+	for _, row := range im.raw {
+		rest.DivMod(rest, q, digit)
+		if c := E(digit.Int64()); !c.IsZero() {
+			out = out.Add(row.ScalarMul(c))
+		}
+	}
+
+	return out
+}
+
+// frees returns the positions that aren't the height of any row in the simplest form of the matrix, in increasing
+// order. Every row decomposes uniquely into a linear combination of the known rows plus a row that's only non-zero in
+// these positions.
+func (im *IncrementalMatrixOf[E]) frees() []int {
+	pivot := make([]bool, im.n)
+	for _, row := range im.simplest {
+		pivot[row.Height()] = true
+	}
+
+	out := []int{}
+	for i, ok := range pivot {
+		if !ok {
+			out = append(out, i)
+		}
+	}
+
+	return out
+}
+
+func (im *IncrementalMatrixOf[E]) freeSize() *big.Int {
+	size := power(fieldSize[E](), im.n-len(im.raw))
+	return size.Sub(size, big.NewInt(1)) // All combinations of free variables except the empty one.
+}
+
+// NovelSize returns the number of rows that are NOT a linear combination of the known rows of the matrix.
+func (im *IncrementalMatrixOf[E]) NovelSize() *big.Int {
+	return new(big.Int).Mul(im.Size(), im.freeSize())
+}
+
+// NovelRow returns the nth row that is NOT a linear combination of the known rows of the matrix. n will be considered
+// modulo im.NovelSize().
+func (im *IncrementalMatrixOf[E]) NovelRow(n *big.Int) RowOf[E] {
+	if im.FullyDefined() {
+		return nil
+	}
+
+	// Extract choices for free variables and rows.
+	free, raw := new(big.Int), new(big.Int).Mod(n, im.NovelSize())
+	raw.DivMod(raw, im.freeSize(), free)
+	free.Add(free, big.NewInt(1))
+
+	q, digit := big.NewInt(int64(fieldSize[E]())), new(big.Int)
+
+	out := NewRowOf[E](im.n)
+	for _, pos := range im.frees() { // Set each free variable to its chosen value.
+		free.DivMod(free, q, digit)
+		out[pos] = E(digit.Int64())
+	}
+
+	return out.Add(im.Row(raw)) // Add the chosen rows from the raw matrix.
+}
+
 // Novel returns a random row that is out of the span of the current matrix.
-func (im *IncrementalMatrix) Novel() Row {
+func (im *IncrementalMatrixOf[E]) Novel() RowOf[E] {
+	return im.NovelFrom(rand.Reader)
+}
+
+// NovelFrom returns a random row that is out of the span of the current matrix, using the random source reader.
+func (im *IncrementalMatrixOf[E]) NovelFrom(reader io.Reader) RowOf[E] {
 	if im.FullyDefined() {
 		return nil
 	}
 
 	for true {
-		cand := GenerateRandomRow(rand.Reader, im.n)
+		cand := GenerateRandomRowOf[E](reader, im.n)
 
 		if !im.IsInSpan(cand) {
 			return cand
@@ -117,30 +298,30 @@ func (im *IncrementalMatrix) Novel() Row {
 }
 
 // pad pads an incremental matrix with empty rows until it is square.
-func (im *IncrementalMatrix) pad(in Matrix) Matrix {
+func (im *IncrementalMatrixOf[E]) pad(in MatrixOf[E]) MatrixOf[E] {
 	out := in.Dup()
 
 	for len(out) < im.n {
-		out = append(out, NewRow(im.n))
+		out = append(out, NewRowOf[E](im.n))
 	}
 
 	return out
 }
 
 // Matrix returns the generated matrix.
-func (im *IncrementalMatrix) Matrix() Matrix {
+func (im *IncrementalMatrixOf[E]) Matrix() MatrixOf[E] {
 	return im.pad(im.raw)
 }
 
 // Inverse returns the generated matrix's inverse.
-func (im *IncrementalMatrix) Inverse() Matrix {
+func (im *IncrementalMatrixOf[E]) Inverse() MatrixOf[E] {
 	sort.Sort(im)
 	return im.pad(im.inverse)
 }
 
-// Dup returns a duplicate of im.
-func (im *IncrementalMatrix) Dup() IncrementalMatrix {
-	return IncrementalMatrix{
+// Dup returns a duplicate of im. The duplicate has no history: markers from im can't be used with it.
+func (im *IncrementalMatrixOf[E]) Dup() IncrementalMatrixOf[E] {
+	return IncrementalMatrixOf[E]{
 		n:        im.n,
 		raw:      im.raw.Dup(),
 		simplest: im.simplest.Dup(),
@@ -149,17 +330,23 @@ func (im *IncrementalMatrix) Dup() IncrementalMatrix {
 }
 
 // Len returns the number of linearly independent rows of the matrix. Part of an implementation of sort.Interface.
-func (im *IncrementalMatrix) Len() int {
+func (im *IncrementalMatrixOf[E]) Len() int {
 	return len(im.raw)
 }
 
 // Less is part of an implementation of sort.Interface.
-func (im *IncrementalMatrix) Less(i, j int) bool {
+func (im *IncrementalMatrixOf[E]) Less(i, j int) bool {
 	return LessThan(im.simplest[i], im.simplest[j])
 }
 
 // Swap is part of an implementation of sort.Interface.
-func (im *IncrementalMatrix) Swap(i, j int) {
+func (im *IncrementalMatrixOf[E]) Swap(i, j int) {
+	im.record(undo[E]{kind: undoSwap, i: i, j: j})
 	im.simplest[i], im.simplest[j] = im.simplest[j], im.simplest[i]
 	im.inverse[i], im.inverse[j] = im.inverse[j], im.inverse[i]
 }
+
+// power returns q^k.
+func power(q, k int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(int64(q)), big.NewInt(int64(k)), nil)
+}
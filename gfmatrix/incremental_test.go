@@ -2,9 +2,12 @@ package gfmatrix
 
 import (
 	"fmt"
+	"math/big"
+	"reflect"
 	"testing"
 
 	"crypto/rand"
+	mrand "math/rand"
 )
 
 func ExampleIncrementalMatrix() {
@@ -84,3 +87,162 @@ func TestIncrementalNovel(t *testing.T) {
 		}
 	}
 }
+
+func TestIncrementalNovelRow(t *testing.T) {
+	im := NewIncrementalMatrixOf[nibble](3)
+	im.Add(RowOf[nibble]{0x3, 0x0, 0x7})
+
+	if im.Size().Int64() != 16 || im.NovelSize().Int64() != 16*255 {
+		t.Fatalf("Size and NovelSize were %v and %v, not 16 and %v.", im.Size(), im.NovelSize(), 16*255)
+	}
+
+	seen := map[string]bool{}
+	for i := int64(0); i < 16; i++ {
+		row := im.Row(big.NewInt(i))
+		if !im.IsInSpan(row) {
+			t.Fatalf("Row returned row that wasn't in span of incremental matrix: %v", row)
+		}
+
+		seen[row.String()] = true
+	}
+
+	for i := int64(0); i < 16*255; i++ {
+		row := im.NovelRow(big.NewInt(i))
+		if im.IsInSpan(row) {
+			t.Fatalf("NovelRow returned row that was in span of incremental matrix: %v", row)
+		}
+
+		seen[row.String()] = true
+	}
+
+	if len(seen) != 16*16*16 {
+		t.Fatalf("Row and NovelRow enumerated %v distinct rows, not every one.", len(seen))
+	}
+
+	a := im.NovelFrom(mrand.New(mrand.NewSource(1)))
+	b := im.NovelFrom(mrand.New(mrand.NewSource(1)))
+	if !a.Equals(b) {
+		t.Fatal("NovelFrom with the same seed returned different rows.")
+	}
+}
+
+func TestIncrementalNovelRowLarge(t *testing.T) {
+	// A linear map on the AES state has 256^16 possible rows, which doesn't fit in an int.
+	im := NewIncrementalMatrix(16)
+	m, _ := GenerateRandom(rand.Reader, 16)
+	for _, row := range m[:10] {
+		im.Add(row)
+	}
+
+	size := new(big.Int).Exp(big.NewInt(256), big.NewInt(10), nil)
+	free := new(big.Int).Exp(big.NewInt(256), big.NewInt(6), nil)
+	novel := new(big.Int).Mul(size, free.Sub(free, big.NewInt(1)))
+
+	if im.Size().Cmp(size) != 0 || im.NovelSize().Cmp(novel) != 0 {
+		t.Fatalf("Size and NovelSize were %v and %v, not %v and %v.", im.Size(), im.NovelSize(), size, novel)
+	}
+
+	for _, n := range []*big.Int{big.NewInt(0), new(big.Int).Sub(novel, big.NewInt(1)), new(big.Int).Rsh(novel, 3)} {
+		if row := im.NovelRow(n); im.IsInSpan(row) {
+			t.Fatalf("NovelRow(%v) returned row that was in span of incremental matrix.", n)
+		} else if row := im.Row(n); !im.IsInSpan(row) {
+			t.Fatalf("Row(%v) returned row that wasn't in span of incremental matrix.", n)
+		}
+	}
+
+	if !im.Row(big.NewInt(1)).Equals(m[0]) || !im.Row(big.NewInt(256)).Equals(m[1]) {
+		t.Fatal("Row didn't use the digits of n as coefficients of the known rows.")
+	}
+}
+
+func TestIncrementalMatrixRollback(t *testing.T) {
+	im := NewIncrementalMatrix(32)
+	m, _ := GenerateRandom(rand.Reader, 32)
+
+	for _, row := range m[0:10] {
+		im.Add(row)
+	}
+
+	before := im.Dup()
+	mark := im.Checkpoint()
+
+	for _, row := range m[10:20] {
+		im.Add(row)
+	}
+	im.Inverse() // Sorts the rows, so swaps have to be undone too.
+
+	middle := im.Dup()
+	inner := im.Checkpoint()
+
+	for _, row := range m[20:] {
+		im.Add(row)
+	}
+	im.Inverse()
+
+	im.Rollback(inner)
+	if !sameIncremental(&im, &middle) {
+		t.Fatalf("Rollback to inner marker didn't restore the matrix.")
+	}
+
+	im.Rollback(mark)
+	if !sameIncremental(&im, &before) {
+		t.Fatalf("Rollback to outer marker didn't restore the matrix.")
+	}
+
+	// The matrix should still work normally after being rolled back.
+	for _, row := range m[10:] {
+		if !im.Add(row) {
+			t.Fatalf("Failed to add row after rollback.")
+		}
+	}
+
+	if !im.Matrix().Equals(m) {
+		t.Fatalf("Matrix after rollback is wrong.")
+	}
+}
+
+func TestIncrementalMatrixCommit(t *testing.T) {
+	im := NewIncrementalMatrix(32)
+	m, _ := GenerateRandom(rand.Reader, 32)
+
+	// Nothing is logged without a checkpoint, including the swaps from sorting.
+	for _, row := range m[0:8] {
+		im.Add(row)
+	}
+	im.Inverse()
+
+	if len(im.log) != 0 {
+		t.Fatalf("Incremental matrix logged %v changes without a checkpoint.", len(im.log))
+	}
+
+	before := im.Dup()
+	outer := im.Checkpoint()
+
+	for _, row := range m[8:16] {
+		im.Add(row)
+	}
+
+	inner := im.Checkpoint()
+	for _, row := range m[16:] {
+		im.Add(row)
+	}
+	im.Inverse()
+
+	// Committing the inner checkpoint keeps its changes, but the outer one can still undo them.
+	im.Commit(inner)
+	if !im.FullyDefined() || len(im.log) == 0 {
+		t.Fatalf("Commit to inner marker lost changes or the undo log.")
+	}
+
+	im.Rollback(outer)
+	if !sameIncremental(&im, &before) {
+		t.Fatalf("Rollback to outer marker didn't undo committed changes.")
+	} else if im.log != nil || im.depth != 0 {
+		t.Fatalf("Undo log wasn't dropped after the last checkpoint was released.")
+	}
+}
+
+func sameIncremental(a, b *IncrementalMatrix) bool {
+	return reflect.DeepEqual(a.raw, b.raw) && reflect.DeepEqual(a.simplest, b.simplest) &&
+		reflect.DeepEqual(a.inverse, b.inverse)
+}
@@ -0,0 +1,139 @@
+package gfmatrix
+
+// Kronecker returns the Kronecker product of a and b: the block matrix whose (i, j)th block is a[i][j] * b. With Vec,
+// it turns matrix equations into linear systems: (A X B).Vec() = Kronecker(B^T, A).Mul(X.Vec()).
+func Kronecker[E Element[E]](a, b MatrixOf[E]) MatrixOf[E] {
+	n, m := a.Size()
+	p, q := b.Size()
+
+	out := GenerateEmptyOf[E](n*p, m*q)
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			if a[i][j].IsZero() {
+				continue
+			}
+
+			for k, row := range b {
+				copy(out[i*p+k][j*q:], row.ScalarMul(a[i][j]))
+			}
+		}
+	}
+
+	return out
+}
+
+// Vec returns the columns of the matrix stacked on top of each other, as one row: entry j*n + i of the output is the
+// entry in row i and column j of an n-row matrix.
+func (e MatrixOf[E]) Vec() RowOf[E] {
+	n, m := e.Size()
+	out := NewRowOf[E](n * m)
+
+	for i, row := range e {
+		for j := 0; j < m; j++ {
+			out[j*n+i] = row[j]
+		}
+	}
+
+	return out
+}
+
+// Unvec is the inverse of Vec: it returns the n-by-m matrix whose stacked columns are v.
+func Unvec[E Element[E]](v RowOf[E], n, m int) MatrixOf[E] {
+	if v.Size() != n*m {
+		panic("Can't unstack row that is wrong size!")
+	}
+
+	out := GenerateEmptyOf[E](n, m)
+
+	for i, row := range out {
+		for j := 0; j < m; j++ {
+			row[j] = v[j*n+i]
+		}
+	}
+
+	return out
+}
+
+// SolveSylvester returns a basis for the space of all X with a * X = X * b, where a is n-by-n and b is m-by-m. With
+// a == b, this is the commutant of a.
+func SolveSylvester[E Element[E]](a, b MatrixOf[E]) (basis []MatrixOf[E]) {
+	n, n2 := a.Size()
+	m, m2 := b.Size()
+	if n != n2 || m != m2 {
+		panic("Can't solve Sylvester equation with non-square matrices!")
+	}
+
+	// (a * X - X * b).Vec() = (I (x) a - b^T (x) I) * X.Vec(), and subtraction is addition.
+	system := Kronecker(GenerateIdentityOf[E](m), a).Add(Kronecker(b.Transpose(), GenerateIdentityOf[E](n)))
+
+	for _, v := range system.NullSpace() {
+		basis = append(basis, Unvec(v, n, m))
+	}
+
+	return
+}
+
+// SolveAXB returns a solution X to a * X * b = c and a basis for the space of all X with a * X * b = 0, so that every
+// solution is the first plus some combination of the second. It returns ErrNoSolution if there's no solution and
+// ErrDimensionMismatch if the sizes of the matrices don't fit together.
+func SolveAXB[E Element[E]](a, b, c MatrixOf[E]) (x MatrixOf[E], basis []MatrixOf[E], err error) {
+	n, p := a.Size()
+	q, m := b.Size()
+	if n2, m2 := c.Size(); n != n2 || m != m2 {
+		return nil, nil, ErrDimensionMismatch
+	}
+
+	// With ua * a * va and ub * b * vb both zero except for an identity in the top-left corner, the equation becomes
+	// one where Y = va^-1 * X * ub^-1 is fixed in the top-left corner and free elsewhere.
+	ua, va, ra := a.rankForm()
+	ub, vb, rb := b.rankForm()
+
+	cc := ua.Compose(c).Compose(vb)
+	y := GenerateEmptyOf[E](p, q)
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			if cc[i][j].IsZero() {
+				continue
+			} else if i >= ra || j >= rb {
+				return nil, nil, ErrNoSolution
+			}
+
+			y[i][j] = cc[i][j]
+		}
+	}
+
+	x = va.Compose(y).Compose(ub)
+
+	// Each free entry (i, j) of Y contributes va * E_ij * ub, the product of the ith column of va with the jth row of ub.
+	for i := 0; i < p; i++ {
+		for j := 0; j < q; j++ {
+			if i < ra && j < rb {
+				continue
+			}
+
+			sol := GenerateEmptyOf[E](p, q)
+			for r, row := range va {
+				sol[r] = ub[j].ScalarMul(row[i])
+			}
+
+			basis = append(basis, sol)
+		}
+	}
+
+	return
+}
+
+// rankForm returns invertible matrices u and v, and the rank r of e, such that u * e * v is zero except for an r-by-r
+// identity matrix in its top-left corner.
+func (e MatrixOf[E]) rankForm() (u, v MatrixOf[E], r int) {
+	_, in := e.Size()
+
+	// Row reduction leaves the pivot rows on top. Column reduction, as row reduction of the transpose, then moves the
+	// pivots into the corner and clears the rest of each pivot row.
+	u, f, frees := e.gaussJordan()
+	vt, _, _ := f.Transpose().gaussJordan()
+
+	return u, vt.Transpose(), in - len(frees)
+}
@@ -0,0 +1,65 @@
+package gfmatrix
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestKronecker(t *testing.T) {
+	a, x, b := GenerateTrueRandom(rand.Reader, 3)[:2], GenerateTrueRandom(rand.Reader, 4)[:3], GenerateTrueRandom(rand.Reader, 4)
+	for i, row := range b {
+		b[i] = row[:1]
+	}
+
+	if !Unvec(x.Vec(), 3, 4).Equals(x) {
+		t.Fatal("Unvec(X.Vec()) != X")
+	} else if !a.Compose(x).Compose(b).Vec().Equals(Kronecker(b.Transpose(), a).Mul(x.Vec())) {
+		t.Fatal("(A X B).Vec() != (B^T (x) A) X.Vec()")
+	}
+}
+
+func TestSolveSylvester(t *testing.T) {
+	// MixColumns commutes exactly with the circulant matrices, a 4-dimensional space.
+	basis := SolveSylvester(mixColumns, mixColumns)
+	if len(basis) != 4 {
+		t.Fatalf("MixColumns had a %v-dimensional commutant, not 4.", len(basis))
+	}
+
+	for _, x := range basis {
+		if !mixColumns.Compose(x).Equals(x.Compose(mixColumns)) {
+			t.Fatal("SolveSylvester returned a non-solution.")
+		}
+	}
+
+	if len(SolveSylvester(GenerateIdentity(3), GenerateIdentity(2))) != 6 {
+		t.Fatal("Every 3-by-2 matrix should intertwine the identities.")
+	}
+}
+
+func TestSolveAXB(t *testing.T) {
+	a, b := GenerateTrueRandom(rand.Reader, 4)[:3], GenerateTrueRandom(rand.Reader, 5)
+	a[2], b[4] = a[0].Add(a[1]), b[3].ScalarMul(7)
+	x0 := GenerateTrueRandom(rand.Reader, 5)[:4]
+
+	c := a.Compose(x0).Compose(b)
+	x, basis, err := SolveAXB(a, b, c)
+	if err != nil {
+		t.Fatalf("SolveAXB returned error on consistent system: %v", err)
+	} else if !a.Compose(x).Compose(b).Equals(c) {
+		t.Fatal("SolveAXB returned a non-solution.")
+	} else if len(basis) != 4*5-2*4 {
+		t.Fatalf("SolveAXB returned %v homogeneous solutions, not %v.", len(basis), 4*5-2*4)
+	}
+
+	for _, y := range basis {
+		if !a.Compose(y).Compose(b).Equals(GenerateEmpty(3, 5)) {
+			t.Fatal("SolveAXB returned a non-solution to the homogeneous equation.")
+		}
+	}
+
+	// Row 2 of a * X * b is always the sum of rows 0 and 1.
+	c[2][0] = c[2][0].Add(1)
+	if _, _, err := SolveAXB(a, b, c); err != ErrNoSolution {
+		t.Fatalf("SolveAXB didn't report inconsistent system: %v", err)
+	}
+}
@@ -0,0 +1,428 @@
+package gfmatrix
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/OpenWhiteBox/primitives/internal/parse"
+
+	"github.com/OpenWhiteBox/primitives/number"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is the number of rows and columns as big-endian
+// 32-bit integers, followed by the entries of each row.
+func (e MatrixOf[E]) MarshalBinary() ([]byte, error) {
+	n, m := e.Size()
+	out := make([]byte, 8, 8+n*m)
+
+	binary.BigEndian.PutUint32(out[0:4], uint32(n))
+	binary.BigEndian.PutUint32(out[4:8], uint32(m))
+
+	for _, row := range e {
+		for _, elem := range row {
+			out = append(out, byte(elem))
+		}
+	}
+
+	return out, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It reads the format written by MarshalBinary.
+func (e *MatrixOf[E]) UnmarshalBinary(data []byte) error {
+	if len(data) < 8 {
+		return fmt.Errorf("%w: binary matrix is missing its header", ErrMalformed)
+	}
+
+	n, m := uint64(binary.BigEndian.Uint32(data[0:4])), uint64(binary.BigEndian.Uint32(data[4:8]))
+	data = data[8:]
+
+	// Both are less than 2^32, so the product can't overflow.
+	if m == 0 && n != 0 {
+		return fmt.Errorf("%w: binary matrix has %v rows but no columns", ErrMalformed, n)
+	} else if n*m != uint64(len(data)) {
+		return fmt.Errorf("%w: %vx%v binary matrix has %v bytes of entries, not %v", ErrMalformed, n, m, len(data), n*m)
+	}
+
+	rows := make([][]byte, n)
+	for i, _ := range rows {
+		rows[i] = data[uint64(i)*m : uint64(i+1)*m]
+	}
+
+	out, err := fromRaw[E](rows, int(m))
+	if err != nil {
+		return err
+	}
+
+	*e = out
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The first line is the number of rows and columns, separated by a
+// space, and each following line is the entries of one row in hex.
+func (e MatrixOf[E]) MarshalText() ([]byte, error) {
+	n, m := e.Size()
+	out := fmt.Sprintf("%v %v\n", n, m)
+
+	for _, row := range e {
+		raw := make([]byte, len(row))
+		for j, elem := range row {
+			raw[j] = byte(elem)
+		}
+
+		out += hex.EncodeToString(raw) + "\n"
+	}
+
+	return []byte(out), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It reads the format written by MarshalText.
+func (e *MatrixOf[E]) UnmarshalText(text []byte) error {
+	lines := strings.Split(strings.TrimSpace(string(text)), "\n")
+
+	var n, m int
+	if _, err := fmt.Sscanf(lines[0], "%d %d", &n, &m); err != nil {
+		return fmt.Errorf("%w: text matrix has bad header %q", ErrMalformed, lines[0])
+	} else if n < 0 || m < 0 {
+		return fmt.Errorf("%w: text matrix has negative dimensions %vx%v", ErrMalformed, n, m)
+	} else if m == 0 && n != 0 {
+		return fmt.Errorf("%w: text matrix has %v rows but no columns", ErrMalformed, n)
+	} else if len(lines)-1 != n {
+		return fmt.Errorf("%w: text matrix has %v rows, not %v", ErrMalformed, len(lines)-1, n)
+	}
+
+	rows := make([][]byte, n)
+	for i, line := range lines[1:] {
+		raw, err := hex.DecodeString(strings.TrimSpace(line))
+		if err != nil {
+			return fmt.Errorf("%w: row %v: %v", ErrMalformed, i, err)
+		}
+
+		rows[i] = raw
+	}
+
+	out, err := fromRaw[E](rows, m)
+	if err != nil {
+		return err
+	}
+
+	*e = out
+	return nil
+}
+
+// fromRaw builds a matrix with m columns out of rows of raw entries, checking that each row is the right length and
+// each entry is in the field. The rows are checked before anything is allocated, so a bad header can't cause a huge
+// allocation.
+func fromRaw[E Element[E]](rows [][]byte, m int) (MatrixOf[E], error) {
+	q := fieldSize[E]()
+
+	for i, raw := range rows {
+		if len(raw) != m {
+			return nil, fmt.Errorf("%w: row %v has %v entries, not %v", ErrMalformed, i, len(raw), m)
+		}
+
+		for _, b := range raw {
+			if int(b) >= q {
+				return nil, fmt.Errorf("%w: row %v has entry %#x outside of the field", ErrMalformed, i, b)
+			}
+		}
+	}
+
+	out := GenerateEmptyOf[E](len(rows), m)
+	for i, raw := range rows {
+		for j, b := range raw {
+			out[i][j] = E(b)
+		}
+	}
+
+	return out, nil
+}
+
+// SageString converts the matrix into a string that can be imported into Sage. Entries are written as polynomials in
+// the generator a of the field.
+func (e MatrixOf[E]) SageString() string {
+	rows := []string{}
+
+	for _, row := range e {
+		elems := []string{}
+		for _, elem := range row {
+			elems = append(elems, sagePoly(uint16(elem), "a"))
+		}
+
+		rows = append(rows, "["+strings.Join(elems, ", ")+"]")
+	}
+
+	var zero E
+	mod := zero.Modulus()
+	field := fmt.Sprintf("GF(2^%v, 'a', modulus=%v)", bits.Len16(mod)-1, strings.Replace(sagePoly(mod, "x"), " ", "", -1))
+
+	return "matrix(" + field + ", [" + strings.Join(rows, ",\n") + "])"
+}
+
+// sagePoly writes a polynomial over GF(2) in the variable v, like "a^7 + a + 1".
+func sagePoly(elem uint16, v string) string {
+	terms := []string{}
+
+	for i := 15; i >= 0; i-- {
+		if elem>>uint(i)&1 == 0 {
+			continue
+		}
+
+		switch i {
+		case 0:
+			terms = append(terms, "1")
+		case 1:
+			terms = append(terms, v)
+		default:
+			terms = append(terms, fmt.Sprintf("%v^%v", v, i))
+		}
+	}
+
+	if len(terms) == 0 {
+		return "0"
+	}
+
+	return strings.Join(terms, " + ")
+}
+
+// ParseOctave parses a matrix in the format written by OctaveString: one row per line, with entries written in decimal
+// and separated by spaces. Octave's bracketed form, like "[2 3; 1 2]", is also accepted.
+func ParseOctave(s string) (Matrix, error) {
+	rows, err := parse.Octave(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	return fromEntries(rows, func(tok string) (number.ByteFieldElem, error) {
+		x, err := strconv.ParseUint(tok, 10, 8)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q isn't a byte", ErrMalformed, tok)
+		}
+
+		return number.ByteFieldElem(x), nil
+	})
+}
+
+// ParseGoString parses a matrix over Rijndael's field in the format written by GoString: a composite literal where each
+// row is a list of entries. Literals of any other type, like the MatrixOf literals written for other fields, are
+// rejected.
+func ParseGoString(s string) (Matrix, error) {
+	rows, err := parse.GoString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	switch typ := strings.TrimSpace(s[:strings.Index(s, "{")]); typ {
+	case "", "Matrix", "gfmatrix.Matrix":
+	default:
+		return nil, fmt.Errorf("%w: %q isn't a matrix over Rijndael's field", ErrMalformed, typ)
+	}
+
+	return fromEntries(rows, func(tok string) (number.ByteFieldElem, error) {
+		x, err := strconv.ParseUint(tok, 0, 8)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q isn't a byte", ErrMalformed, tok)
+		}
+
+		return number.ByteFieldElem(x), nil
+	})
+}
+
+// ParseSage parses a matrix over Rijndael's field written in Sage's syntax. See ParseSageOf.
+func ParseSage(s string) (Matrix, error) {
+	return ParseSageOf[number.ByteFieldElem](s)
+}
+
+// ParseSageOf parses a matrix over the field E written in Sage's syntax, like the output of SageString, either as a
+// list of rows or as dimensions and a flat list of entries.
+//
+// If the field is a constructor, like "GF(2^4, 'a', modulus=x^4+x+1)", its size and modulus have to be E's. A field
+// given by name, like "K", can't be checked and is taken to be E. Entries may be polynomials in the field's generator,
+// like "a^7 + a + 1" or "a**7 + a + 1", integers (which are reduced mod 2, as Sage would), or "K.fetch_int(n)" and
+// "K.from_integer(n)", which give the element whose bits are those of n.
+func ParseSageOf[E Element[E]](s string) (MatrixOf[E], error) {
+	field, rows, err := parse.Sage(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	} else if err := checkSageField[E](field); err != nil {
+		return nil, err
+	}
+
+	return fromEntries(rows, parseSageElem[E])
+}
+
+// checkSageField returns an error if a Sage field constructor doesn't give the field E.
+func checkSageField[E Element[E]](field string) error {
+	field = strings.Join(strings.Fields(field), "")
+	if isIdent(field) {
+		return nil
+	} else if !strings.HasPrefix(field, "GF(") || !strings.HasSuffix(field, ")") {
+		return fmt.Errorf("%w: %q isn't a finite field", ErrMalformed, field)
+	}
+
+	args, err := parse.TopLevel(field[len("GF(") : len(field)-1])
+	if err != nil || len(args) == 0 {
+		return fmt.Errorf("%w: %q isn't a finite field", ErrMalformed, field)
+	}
+
+	size, err := strconv.Atoi(args[0])
+	if k, ok := sagePower(args[0], "2"); ok {
+		size, err = 1<<uint(k), nil
+	}
+	if err != nil || size != fieldSize[E]() {
+		return fmt.Errorf("%w: %q doesn't have %v elements", ErrMalformed, field, fieldSize[E]())
+	} else if size == 2 {
+		return nil
+	}
+
+	var zero E
+	for _, arg := range args[1:] {
+		if !strings.HasPrefix(arg, "modulus=") {
+			continue
+		}
+
+		exps, err := sageExponents(strings.TrimPrefix(arg, "modulus="))
+		if err != nil {
+			return err
+		}
+
+		mod := uint16(0)
+		for _, k := range exps {
+			if k > 15 {
+				return fmt.Errorf("%w: modulus of %q has degree %v", ErrMalformed, field, k)
+			}
+			mod ^= 1 << uint(k)
+		}
+
+		if mod != zero.Modulus() {
+			return fmt.Errorf("%w: %q doesn't have modulus %#x", ErrMalformed, field, zero.Modulus())
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q doesn't give its modulus, so it can't be checked", ErrMalformed, field)
+}
+
+// parseSageElem parses one entry of a Sage matrix over E.
+func parseSageElem[E Element[E]](tok string) (E, error) {
+	tok = strings.Join(strings.Fields(tok), "")
+
+	for _, method := range []string{"fetch_int(", "from_integer("} {
+		if pos := strings.Index(tok, method); pos != -1 && strings.HasSuffix(tok, ")") {
+			x, err := strconv.ParseUint(tok[pos+len(method):len(tok)-1], 10, 16)
+			if err != nil || x >= uint64(fieldSize[E]()) {
+				return 0, fmt.Errorf("%w: %q isn't in the field", ErrMalformed, tok)
+			}
+
+			return E(x), nil
+		}
+	}
+
+	exps, err := sageExponents(tok)
+	if err != nil {
+		return 0, err
+	}
+
+	// The generator is x mod the modulus, which is 1 in GF(2). Its powers repeat with period q-1.
+	gen, order := E(2), fieldSize[E]()-1
+	if order == 1 {
+		gen = 1
+	}
+
+	out := E(0)
+	for _, k := range exps {
+		power := E(1)
+		for i := 0; i < k%order; i++ {
+			power = power.Mul(gen)
+		}
+
+		out = out.Add(power)
+	}
+
+	return out, nil
+}
+
+// sageExponents splits a polynomial written in Sage's syntax, like "a^7 + a**2 + a + 3", into the exponents of its
+// terms. Integer terms are reduced mod 2, so they're either dropped or give the exponent 0. Every power has to be of
+// some identifier, but it isn't checked which.
+func sageExponents(tok string) (exps []int, err error) {
+	for _, term := range strings.Split(strings.Join(strings.Fields(tok), ""), "+") {
+		if x, err := strconv.ParseInt(term, 10, 64); err == nil {
+			if x&1 == 1 {
+				exps = append(exps, 0)
+			}
+			continue
+		}
+
+		// Anything else is a power of the generator: a, a^k or a**k.
+		k, name := 1, term
+		if pos := strings.IndexAny(term, "^*"); pos != -1 {
+			var ok bool
+			if k, ok = sagePower(term, term[:pos]); !ok {
+				return nil, fmt.Errorf("%w: bad exponent in %q", ErrMalformed, term)
+			}
+
+			name = term[:pos]
+		}
+
+		if !isIdent(name) {
+			return nil, fmt.Errorf("%w: %q isn't a power of the generator", ErrMalformed, term)
+		}
+
+		exps = append(exps, k)
+	}
+
+	return exps, nil
+}
+
+// sagePower parses tok as base^k or base**k, and returns k.
+func sagePower(tok, base string) (int, bool) {
+	for _, op := range []string{"^", "**"} {
+		if strings.HasPrefix(tok, base+op) {
+			k, err := strconv.Atoi(tok[len(base+op):])
+			return k, err == nil && k >= 0
+		}
+	}
+
+	return 0, false
+}
+
+// isIdent returns true if s is an identifier.
+func isIdent(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(r rune) bool { return !isIdentRune(r) }) == -1 &&
+		!('0' <= s[0] && s[0] <= '9')
+}
+
+// isIdentRune returns true if r can appear in an identifier.
+func isIdentRune(r rune) bool {
+	return r == '_' || '0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z'
+}
+
+// fromEntries builds a matrix out of rows of tokens, parsing each token into an entry.
+func fromEntries[E Element[E]](rows [][]string, parseEntry func(string) (E, error)) (MatrixOf[E], error) {
+	out := MatrixOf[E]{}
+
+	for i, toks := range rows {
+		if len(toks) != len(rows[0]) {
+			return nil, fmt.Errorf("%w: row %v has %v entries, not %v", ErrMalformed, i, len(toks), len(rows[0]))
+		}
+
+		row := NewRowOf[E](len(toks))
+		for j, tok := range toks {
+			elem, err := parseEntry(tok)
+			if err != nil {
+				return nil, err
+			}
+
+			row[j] = elem
+		}
+
+		out = append(out, row)
+	}
+
+	return out, nil
+}
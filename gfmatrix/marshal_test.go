@@ -0,0 +1,112 @@
+package gfmatrix
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestMarshal(t *testing.T) {
+	m := GenerateTrueRandom(rand.Reader, 6)[:4]
+
+	bin, _ := m.MarshalBinary()
+	text, _ := m.MarshalText()
+
+	var fromBin, fromText Matrix
+	if err := fromBin.UnmarshalBinary(bin); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	} else if err := fromText.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	} else if !fromBin.Equals(m) || !fromText.Equals(m) {
+		t.Fatal("Marshaling and unmarshaling didn't give the original matrix.")
+	}
+
+	parsers := []struct {
+		name  string
+		parse func(string) (Matrix, error)
+		in    string
+	}{
+		{"Octave", ParseOctave, m.OctaveString()},
+		{"Go", ParseGoString, m.GoString()},
+		{"Sage", ParseSage, m.SageString()},
+	}
+
+	for _, p := range parsers {
+		parsed, err := p.parse(p.in)
+		if err != nil {
+			t.Fatalf("Parse%v failed: %v", p.name, err)
+		} else if !parsed.Equals(m) {
+			t.Fatalf("Parse%v didn't give the original matrix.", p.name)
+		}
+	}
+}
+
+func TestParseSage(t *testing.T) {
+	m, err := ParseSage("matrix(K, 2, 2, [a, a + 1, K.fetch_int(3), a**8 + 3])")
+	if err != nil {
+		t.Fatalf("ParseSage failed: %v", err)
+	}
+
+	// a^8 = a^4 + a^3 + a + 1 in Rijndael's field.
+	if real := (Matrix{Row{2, 3}, Row{3, 0x1a}}); !m.Equals(real) {
+		t.Fatalf("ParseSage gave the wrong matrix:\n%v", m)
+	}
+
+	if _, err := ParseSage("matrix(K, [[a + b^x]])"); err == nil {
+		t.Fatal("ParseSage accepted a malformed entry.")
+	}
+}
+
+func TestUnmarshalMalformed(t *testing.T) {
+	texts := []string{"1 -8\n00", "-1 1\n", "2 0\n\n", "1 99999999999\n00", "1 2\n00ff00"}
+	for _, text := range texts {
+		var m Matrix
+		if err := m.UnmarshalText([]byte(text)); err == nil {
+			t.Fatalf("UnmarshalText accepted malformed matrix %q.", text)
+		}
+	}
+
+	bins := [][]byte{
+		{0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00}, // Rows without columns.
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, // Dimensions whose product overflows 32 bits.
+		{0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x02, 0xff},
+	}
+	for _, bin := range bins {
+		var m Matrix
+		if err := m.UnmarshalBinary(bin); err == nil {
+			t.Fatalf("UnmarshalBinary accepted malformed matrix %x.", bin)
+		}
+	}
+
+	// Entries outside of GF(2^4).
+	var m MatrixOf[nibble]
+	if err := m.UnmarshalText([]byte("1 2\n0310")); err == nil {
+		t.Fatal("UnmarshalText accepted entry outside of the field.")
+	}
+}
+
+func TestParseSageOf(t *testing.T) {
+	// a^4 = a + 1 in GF(2^4) with x^4 + x + 1, and a^15 = 1.
+	m, err := ParseSageOf[nibble]("matrix(GF(2^4, 'a', modulus=x^4+x+1), [[a^4, a**1000000007, K.fetch_int(15)]])")
+	if err != nil {
+		t.Fatalf("ParseSageOf failed: %v", err)
+	} else if real := (MatrixOf[nibble]{RowOf[nibble]{0x03, 0x04, 0x0f}}); !m.Equals(real) {
+		t.Fatalf("ParseSageOf gave the wrong matrix:\n%v", m)
+	}
+
+	n := GenerateTrueRandomOf[nibble](rand.Reader, 4)
+	if parsed, err := ParseSageOf[nibble](n.SageString()); err != nil || !parsed.Equals(n) {
+		t.Fatalf("ParseSageOf didn't round-trip SageString: %v", err)
+	}
+
+	bad := []string{
+		"matrix(GF(2^4, 'a', modulus=x^4+x+1), [[a]])",           // The wrong size.
+		"matrix(GF(2^8, 'a', modulus=x^8+x^4+x^3+x^2+1), [[a]])", // The wrong modulus.
+		"matrix(GF(2^8, 'a'), [[a]])",                            // No modulus.
+		"matrix(GF(2^8, 'a', modulus=x^8+x^4+x^3+x+1), [[K.fetch_int(256)]])",
+	}
+	for _, cand := range bad {
+		if _, err := ParseSage(cand); err == nil {
+			t.Fatalf("ParseSage accepted %q.", cand)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package gfmatrix
+
+import (
+	"encoding/binary"
+	"io"
+	"math/bits"
+	"sort"
+)
+
+// IsInvolutory returns true if the matrix is its own inverse. An involutory linear layer costs the same to invert as to
+// evaluate.
+func (e MatrixOf[E]) IsInvolutory() bool {
+	n, m := e.Size()
+	if n != m {
+		return false
+	}
+
+	return e.Compose(e).Equals(GenerateIdentityOf[E](n))
+}
+
+// GenerateCirculant returns the circulant matrix with the given first row: each row is the one above rotated one
+// position to the right. AES's MixColumns is GenerateCirculant(Row{2, 3, 1, 1}).
+func GenerateCirculant[E Element[E]](first RowOf[E]) MatrixOf[E] {
+	n := first.Size()
+	out := GenerateEmptyOf[E](n, n)
+
+	for i, row := range out {
+		for j, x := range first {
+			row[(i+j)%n] = x
+		}
+	}
+
+	return out
+}
+
+// GenerateCauchy returns the Cauchy matrix with entries 1 / (x_i + y_j). It's MDS if the entries of x are distinct, the
+// entries of y are distinct, and no entry of x is in y--it panics otherwise.
+func GenerateCauchy[E Element[E]](x, y RowOf[E]) MatrixOf[E] {
+	if !distinct(x, y) {
+		panic("Can't build Cauchy matrix from repeated elements!")
+	}
+
+	out := GenerateEmptyOf[E](x.Size(), y.Size())
+
+	for i, x_i := range x {
+		for j, y_j := range y {
+			out[i][j] = x_i.Add(y_j).Invert()
+		}
+	}
+
+	return out
+}
+
+// GenerateVandermonde returns the len(x)-by-m Vandermonde matrix with entries x_i^j.
+func GenerateVandermonde[E Element[E]](x RowOf[E], m int) MatrixOf[E] {
+	out := GenerateEmptyOf[E](x.Size(), m)
+
+	for i, x_i := range x {
+		power := E(1)
+
+		for j := 0; j < m; j++ {
+			out[i][j] = power
+			power = power.Mul(x_i)
+		}
+	}
+
+	return out
+}
+
+// GenerateVandermondeMDS returns the MDS matrix B * A^-1, where A and B are the Vandermonde matrices with points x and y.
+// Stacked, A and B generate a Reed-Solomon code, so every square submatrix of B * A^-1 is non-singular. It panics unless
+// x and y are the same size and all of their entries are distinct.
+func GenerateVandermondeMDS[E Element[E]](x, y RowOf[E]) MatrixOf[E] {
+	if x.Size() != y.Size() {
+		panic("Can't build Vandermonde MDS matrix from point sets of different sizes!")
+	} else if !distinct(x, y) {
+		panic("Can't build Vandermonde MDS matrix from repeated elements!")
+	}
+
+	n := x.Size()
+	aInv, _ := GenerateVandermonde(x, n).Invert()
+
+	return GenerateVandermonde(y, n).Compose(aInv)
+}
+
+// GenerateCompanion returns the companion matrix with the given last row: it shifts its input up by one position and
+// puts the dot product of the input with last in the bottom position.
+func GenerateCompanion[E Element[E]](last RowOf[E]) MatrixOf[E] {
+	n := last.Size()
+	out := GenerateEmptyOf[E](n, n)
+
+	for i := 0; i < n-1; i++ {
+		out[i][i+1] = 0x01
+	}
+	copy(out[n-1], last)
+
+	return out
+}
+
+// GenerateSerial returns the nth power of the n-by-n companion matrix with the given last row, as in the recursive
+// linear layers of LED and PHOTON. The matrix is evaluated by applying the companion matrix n times, which is very
+// cheap in hardware, and is MDS for well-chosen rows, like (4, 1, 2, 2) over GF(2^4).
+func GenerateSerial[E Element[E]](last RowOf[E]) MatrixOf[E] {
+	a := GenerateCompanion(last)
+	out := GenerateIdentityOf[E](last.Size())
+
+	for i := 0; i < last.Size(); i++ {
+		out = a.Compose(out)
+	}
+
+	return out
+}
+
+// distinct returns true if no element appears twice in the concatenation of x and y.
+func distinct[E Element[E]](x, y RowOf[E]) bool {
+	seen := make([]bool, fieldSize[E]())
+
+	for _, row := range []RowOf[E]{x, y} {
+		for _, elem := range row {
+			if seen[elem] {
+				return false
+			}
+			seen[elem] = true
+		}
+	}
+
+	return true
+}
+
+// XORCount returns the number of XORs it takes to multiply by the matrix, when each entry is multiplied in as a binary
+// matrix and the products in each row are summed. This is the usual (direct) metric for the cost of a linear layer.
+func (e MatrixOf[E]) XORCount() (count int) {
+	k := fieldDegree[E]()
+
+	for _, row := range e {
+		terms := 0
+
+		for _, elem := range row {
+			if !elem.IsZero() {
+				count += elemXORCount(elem)
+				terms++
+			}
+		}
+
+		if terms > 1 {
+			count += k * (terms - 1)
+		}
+	}
+
+	return
+}
+
+// elemXORCount returns the number of XORs it takes to multiply by a non-zero field element: the number of ones in the
+// binary matrix of multiplication by elem, minus one for each row.
+func elemXORCount[E Element[E]](elem E) int {
+	k := fieldDegree[E]()
+	ones := 0
+
+	for j := 0; j < k; j++ {
+		ones += bits.OnesCount16(uint16(elem.Mul(E(1) << uint(j))))
+	}
+
+	return ones - k
+}
+
+// MDSSearch configures a search for MDS matrices with a low XOR count. The zero value tries 1024 candidates over every
+// non-zero element.
+type MDSSearch struct {
+	// Tries is the number of candidates to try. Zero means 1024.
+	Tries int
+	// Elements, if non-zero, restricts entries to the given number of non-zero field elements that are cheapest to
+	// multiply by.
+	Elements int
+	// MaxXOR, if non-zero, stops the search as soon as an MDS matrix with at most this XOR count is found.
+	MaxXOR int
+}
+
+// SearchCirculantMDS searches for an n-by-n circulant MDS matrix over E with a low XOR count, drawing candidates from
+// the random source reader. It returns the cheapest MDS matrix found, or false if none was.
+func SearchCirculantMDS[E Element[E]](reader io.Reader, n int, opts MDSSearch) (MatrixOf[E], bool) {
+	if opts.Tries == 0 {
+		opts.Tries = 1024
+	}
+
+	// Sort the non-zero elements by cost, breaking ties by value so the order is deterministic.
+	elems := make([]E, 0, fieldSize[E]()-1)
+	for x := 1; x < fieldSize[E](); x++ {
+		elems = append(elems, E(x))
+	}
+
+	sort.SliceStable(elems, func(i, j int) bool { return elemXORCount(elems[i]) < elemXORCount(elems[j]) })
+	if opts.Elements > 0 && opts.Elements < len(elems) {
+		elems = elems[:opts.Elements]
+	}
+
+	var best MatrixOf[E]
+	bestCount, buf := 0, make([]byte, 2*n)
+
+	for t := 0; t < opts.Tries; t++ {
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			break
+		}
+
+		first := NewRowOf[E](n)
+		for i, _ := range first {
+			first[i] = elems[int(binary.LittleEndian.Uint16(buf[2*i:]))%len(elems)]
+		}
+
+		cand := GenerateCirculant(first)
+		if count := cand.XORCount(); (best == nil || count < bestCount) && cand.IsMDS() {
+			best, bestCount = cand, count
+
+			if opts.MaxXOR > 0 && bestCount <= opts.MaxXOR {
+				break
+			}
+		}
+	}
+
+	return best, best != nil
+}
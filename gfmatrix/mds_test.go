@@ -0,0 +1,100 @@
+package gfmatrix
+
+import (
+	"testing"
+
+	mrand "math/rand"
+
+	"github.com/OpenWhiteBox/primitives/number"
+)
+
+type nibble = number.FieldElem[number.Nibble]
+
+func TestIsInvolutory(t *testing.T) {
+	// The Hadamard matrix with entries a_(i xor j) squares to (sum of a_i)^2 times the identity.
+	a := Row{1, 2, 4, 6}
+	h := GenerateEmpty(4, 4)
+	for i, row := range h {
+		for j, _ := range row {
+			row[j] = a[i^j]
+		}
+	}
+
+	if !h.IsInvolutory() {
+		t.Fatal("Involutory Hadamard matrix isn't involutory.")
+	} else if mixColumns.IsInvolutory() {
+		t.Fatal("MixColumns is involutory.")
+	}
+}
+
+func TestGenerateCirculant(t *testing.T) {
+	m := GenerateCirculant(Row{2, 3, 1, 1})
+
+	if !m.Equals(mixColumns) {
+		t.Fatalf("Circulant matrix isn't MixColumns:\n%v", m)
+	} else if count := m.XORCount(); count != 152 {
+		t.Fatalf("MixColumns had XOR count %v, not 152.", count)
+	}
+}
+
+func TestGenerateCauchy(t *testing.T) {
+	m := GenerateCauchy(Row{1, 2, 3, 4}, Row{5, 6, 7, 8})
+
+	if !m.IsMDS() {
+		t.Fatal("Cauchy matrix isn't MDS.")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("GenerateCauchy accepted repeated elements.")
+		}
+	}()
+	GenerateCauchy(Row{1, 2}, Row{2, 3})
+}
+
+func TestGenerateVandermondeMDS(t *testing.T) {
+	m := GenerateVandermondeMDS(Row{0, 1, 2, 3, 4}, Row{5, 6, 7, 8, 9})
+	if !m.IsMDS() {
+		t.Fatal("Vandermonde-based matrix over GF(2^8) isn't MDS.")
+	}
+
+	n := GenerateVandermondeMDS(RowOf[nibble]{0, 1, 2, 3}, RowOf[nibble]{4, 5, 6, 7})
+	if !n.IsMDS() {
+		t.Fatal("Vandermonde-based matrix over GF(2^4) isn't MDS.")
+	}
+}
+
+func TestGenerateSerial(t *testing.T) {
+	// LED's MixColumnsSerial matrix.
+	led := MatrixOf[nibble]{
+		{0x4, 0x1, 0x2, 0x2},
+		{0x8, 0x6, 0x5, 0x6},
+		{0xb, 0xe, 0xa, 0x9},
+		{0x2, 0x2, 0xf, 0xb},
+	}
+
+	m := GenerateSerial(RowOf[nibble]{4, 1, 2, 2})
+	if !m.Equals(led) {
+		t.Fatalf("Serial matrix isn't LED's:\n%v", m)
+	} else if !m.IsMDS() {
+		t.Fatal("LED's matrix isn't MDS.")
+	}
+}
+
+func TestSearchCirculantMDS(t *testing.T) {
+	opts := MDSSearch{Tries: 256, Elements: 8}
+
+	m, ok := SearchCirculantMDS[number.ByteFieldElem](mrand.New(mrand.NewSource(1)), 4, opts)
+	if !ok {
+		t.Fatal("Search failed to find a circulant MDS matrix.")
+	} else if !m.IsMDS() {
+		t.Fatal("Search returned a matrix that isn't MDS.")
+	} else if m.XORCount() > mixColumns.XORCount() {
+		t.Fatalf("Search returned a matrix that's more expensive than MixColumns:\n%v", m)
+	}
+
+	again, _ := SearchCirculantMDS[number.ByteFieldElem](mrand.New(mrand.NewSource(1)), 4, opts)
+	if !again.Equals(m) {
+		t.Fatal("Search with the same seed returned a different matrix.")
+	}
+}
@@ -0,0 +1,90 @@
+package gfmatrix
+
+import (
+	"github.com/OpenWhiteBox/primitives/internal/matching"
+)
+
+// GenerateMonomial returns the monomial matrix P * D, where P is the permutation matrix with a one in row i and column
+// perm[i] and D is the diagonal matrix with diag on its diagonal.
+func GenerateMonomial[E Element[E]](perm []int, diag RowOf[E]) MatrixOf[E] {
+	if len(perm) != diag.Size() {
+		panic("Can't build monomial matrix from permutation and diagonal of different sizes!")
+	}
+
+	out := GenerateEmptyOf[E](len(perm), len(perm))
+	for i, j := range perm {
+		out[i][j] = diag[j]
+	}
+
+	return out
+}
+
+// AsMonomial factors a monomial matrix--one with exactly one non-zero entry in each row and column--into a permutation
+// and a diagonal, such that e = GenerateMonomial(perm, diag). It returns false if e isn't monomial.
+func (e MatrixOf[E]) AsMonomial() (perm []int, diag RowOf[E], ok bool) {
+	n, m := e.Size()
+	if n != m {
+		return nil, nil, false
+	}
+
+	perm, diag = make([]int, n), NewRowOf[E](n)
+	used := make([]bool, n)
+
+	for i, row := range e {
+		j := row.Height()
+		if j == -1 || used[j] {
+			return nil, nil, false
+		}
+
+		for _, e_k := range row[j+1:] {
+			if !e_k.IsZero() {
+				return nil, nil, false
+			}
+		}
+
+		perm[i], diag[j], used[j] = j, row[j], true
+	}
+
+	return perm, diag, true
+}
+
+// MonomialEquivalent returns a permutation and a diagonal such that e = P * D * f, with P and D as in GenerateMonomial.
+// That is, every row of e is a non-zero multiple of a different row of f. It returns false if there aren't any. f can
+// be singular or non-square; if it's invertible, this is the same as e * f^-1 being monomial.
+func (e MatrixOf[E]) MonomialEquivalent(f MatrixOf[E]) (perm []int, diag RowOf[E], ok bool) {
+	n, m := e.Size()
+	if p, q := f.Size(); n != p || m != q {
+		return nil, nil, false
+	}
+
+	// scale[i][j] is the scalar that row j of f is multiplied by to give row i of e, or zero if there isn't one.
+	scale := GenerateEmptyOf[E](n, n)
+	for i, e_i := range e {
+		for j, f_j := range f {
+			h := f_j.Height()
+			if h != e_i.Height() {
+				continue
+			} else if h == -1 {
+				scale[i][j] = 0x01
+				continue
+			}
+
+			c := e_i[h].Mul(f_j[h].Invert())
+			if e_i.Equals(f_j.ScalarMul(c)) {
+				scale[i][j] = c
+			}
+		}
+	}
+
+	perm, ok = matching.Perfect(n, func(i, j int) bool { return !scale[i][j].IsZero() })
+	if !ok {
+		return nil, nil, false
+	}
+
+	diag = NewRowOf[E](n)
+	for i, j := range perm {
+		diag[j] = scale[i][j]
+	}
+
+	return perm, diag, true
+}
@@ -0,0 +1,60 @@
+package gfmatrix
+
+import (
+	"crypto/rand"
+	"testing"
+
+	mrand "math/rand"
+)
+
+// randomMonomial returns the permutation and diagonal of a random n-by-n monomial matrix.
+func randomMonomial(n int) ([]int, Row) {
+	diag := GenerateRandomRow(rand.Reader, n)
+	for i, d := range diag {
+		if d.IsZero() {
+			diag[i] = 0x01
+		}
+	}
+
+	return mrand.Perm(n), diag
+}
+
+func TestAsMonomial(t *testing.T) {
+	perm, diag := randomMonomial(8)
+	m := GenerateMonomial(perm, diag)
+
+	p, d, ok := m.AsMonomial()
+	if !ok {
+		t.Fatal("AsMonomial rejected a monomial matrix.")
+	} else if !GenerateMonomial(p, d).Equals(m) || !d.Equals(diag) {
+		t.Fatal("AsMonomial returned the wrong factorization.")
+	}
+
+	m[3][perm[5]] = 0x01
+	if _, _, ok := m.AsMonomial(); ok {
+		t.Fatal("AsMonomial accepted a matrix with two entries in one column.")
+	} else if _, _, ok := GenerateIdentity(8).Add(GenerateIdentity(8)).AsMonomial(); ok {
+		t.Fatal("AsMonomial accepted the zero matrix.")
+	}
+}
+
+func TestMonomialEquivalent(t *testing.T) {
+	perm, diag := randomMonomial(6)
+
+	// f is singular, so e * f^-1 can't be used.
+	f := GenerateTrueRandom(rand.Reader, 6)
+	f[4] = f[1].ScalarMul(0x09)
+	e := GenerateMonomial(perm, diag).Compose(f)
+
+	p, d, ok := e.MonomialEquivalent(f)
+	if !ok {
+		t.Fatal("MonomialEquivalent failed to find monomial matrix.")
+	} else if !GenerateMonomial(p, d).Compose(f).Equals(e) {
+		t.Fatal("MonomialEquivalent returned the wrong monomial matrix.")
+	}
+
+	e[2][0] = e[2][0].Add(0x01)
+	if _, _, ok := e.MonomialEquivalent(f); ok {
+		t.Fatal("MonomialEquivalent accepted matrices that aren't equivalent.")
+	}
+}
@@ -0,0 +1,374 @@
+package gfmatrix
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/OpenWhiteBox/primitives/internal/poly"
+	"github.com/OpenWhiteBox/primitives/number"
+)
+
+// PolynomialOf is a polynomial over the field E. Entry i is the coefficient of x^i. Polynomials are kept trimmed, so
+// the zero polynomial is empty.
+type PolynomialOf[E Element[E]] []E
+
+// Polynomial is a polynomial over GF(2^8).
+type Polynomial = PolynomialOf[number.ByteFieldElem]
+
+// FactorOf is an irreducible factor of a polynomial over E and its multiplicity.
+type FactorOf[E Element[E]] struct {
+	Poly         PolynomialOf[E]
+	Multiplicity int
+}
+
+// Factor is an irreducible factor of a polynomial over GF(2^8) and its multiplicity.
+type Factor = FactorOf[number.ByteFieldElem]
+
+// monomial returns c * x^d.
+func monomial[E Element[E]](c E, d int) PolynomialOf[E] {
+	p := make(PolynomialOf[E], d+1)
+	p[d] = c
+
+	return p.trim()
+}
+
+// elements is the arithmetic of E, in the form the poly package takes.
+type elements[E Element[E]] struct{}
+
+func (elements[E]) Add(a, b E) E { return a.Add(b) }
+func (elements[E]) Mul(a, b E) E { return a.Mul(b) }
+func (elements[E]) Invert(a E) E { return a.Invert() }
+
+// trim removes leading zero coefficients.
+func (p PolynomialOf[E]) trim() PolynomialOf[E] {
+	return poly.Trim(p)
+}
+
+// coefficient returns the coefficient of x^i.
+func (p PolynomialOf[E]) coefficient(i int) E {
+	if i >= len(p) {
+		return 0
+	}
+
+	return p[i]
+}
+
+// Degree returns the degree of the polynomial, or -1 if it's zero.
+func (p PolynomialOf[E]) Degree() int {
+	return len(p.trim()) - 1
+}
+
+// IsZero returns true if the polynomial is zero.
+func (p PolynomialOf[E]) IsZero() bool {
+	return p.Degree() == -1
+}
+
+// IsOne returns true if the polynomial is the constant 1.
+func (p PolynomialOf[E]) IsOne() bool {
+	return p.Degree() == 0 && p[0].IsOne()
+}
+
+// Equals returns true if two polynomials are equal and false otherwise.
+func (p PolynomialOf[E]) Equals(q PolynomialOf[E]) bool {
+	p, q = p.trim(), q.trim()
+	if len(p) != len(q) {
+		return false
+	}
+
+	for i, _ := range p {
+		if p[i] != q[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// less orders polynomials by degree, and then by their coefficients from the top down.
+func (p PolynomialOf[E]) less(q PolynomialOf[E]) bool {
+	p, q = p.trim(), q.trim()
+	if len(p) != len(q) {
+		return len(p) < len(q)
+	}
+
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i] != q[i] {
+			return p[i] < q[i]
+		}
+	}
+
+	return false
+}
+
+// Add returns p + q.
+func (p PolynomialOf[E]) Add(q PolynomialOf[E]) PolynomialOf[E] {
+	return poly.Add[E](elements[E]{}, p, q)
+}
+
+// Mul returns p * q.
+func (p PolynomialOf[E]) Mul(q PolynomialOf[E]) PolynomialOf[E] {
+	return poly.Mul[E](elements[E]{}, p, q)
+}
+
+// ScalarMul returns c * p.
+func (p PolynomialOf[E]) ScalarMul(c E) PolynomialOf[E] {
+	return poly.ScalarMul[E](elements[E]{}, p, c)
+}
+
+// Monic returns p divided by its leading coefficient. It panics if p is zero.
+func (p PolynomialOf[E]) Monic() PolynomialOf[E] {
+	return poly.Monic[E](elements[E]{}, p)
+}
+
+// DivMod returns the quotient and remainder of dividing p by q. It panics if q is zero.
+func (p PolynomialOf[E]) DivMod(q PolynomialOf[E]) (quo, rem PolynomialOf[E]) {
+	return poly.DivMod[E](elements[E]{}, p, q)
+}
+
+// Mod returns p mod q.
+func (p PolynomialOf[E]) Mod(q PolynomialOf[E]) PolynomialOf[E] {
+	_, rem := p.DivMod(q)
+	return rem
+}
+
+// GCD returns the monic greatest common divisor of p and q, or zero if both are zero.
+func (p PolynomialOf[E]) GCD(q PolynomialOf[E]) PolynomialOf[E] {
+	return poly.GCD[E](elements[E]{}, p, q)
+}
+
+// Derivative returns the formal derivative of p.
+func (p PolynomialOf[E]) Derivative() PolynomialOf[E] {
+	out := make(PolynomialOf[E], len(p))
+
+	// In characteristic 2, the derivative of x^i is x^(i-1) when i is odd and zero when i is even.
+	for i := 1; i <= p.Degree(); i += 2 {
+		out[i-1] = p[i]
+	}
+
+	return out.trim()
+}
+
+// EvalElem returns the polynomial evaluated at a field element.
+func (p PolynomialOf[E]) EvalElem(x E) E {
+	out := E(0)
+
+	// Horner's method.
+	for i := p.Degree(); i >= 0; i-- {
+		out = out.Mul(x).Add(p[i])
+	}
+
+	return out
+}
+
+// Eval returns the polynomial evaluated at a square matrix.
+func (p PolynomialOf[E]) Eval(e MatrixOf[E]) MatrixOf[E] {
+	n, _ := e.Size()
+	out := GenerateEmptyOf[E](n, n)
+
+	// Horner's method.
+	for i := p.Degree(); i >= 0; i-- {
+		out = out.Compose(e)
+
+		for j := 0; j < n; j++ {
+			out[j][j] = out[j][j].Add(p[i])
+		}
+	}
+
+	return out
+}
+
+// Roots returns the distinct roots of a non-zero polynomial in E, in increasing order.
+func (p PolynomialOf[E]) Roots() (roots []E) {
+	if p.IsZero() {
+		panic("Can't find roots of zero polynomial!")
+	}
+
+	for x := 0; x < fieldSize[E](); x++ {
+		if p.EvalElem(E(x)).IsZero() {
+			roots = append(roots, E(x))
+		}
+	}
+
+	return
+}
+
+// Factor returns the monic irreducible factors of a non-zero polynomial and their multiplicities, ordered by degree and
+// then by coefficients. The leading coefficient of p is dropped.
+func (p PolynomialOf[E]) Factor() (factors []FactorOf[E]) {
+	if p.IsZero() {
+		panic("Can't factor zero polynomial!")
+	}
+
+	for _, sf := range p.Monic().squareFree() {
+		for _, f := range sf.Poly.berlekamp() {
+			factors = append(factors, FactorOf[E]{f, sf.Multiplicity})
+		}
+	}
+
+	sort.Slice(factors, func(i, j int) bool { return factors[i].Poly.less(factors[j].Poly) })
+
+	return
+}
+
+// IsIrreducible returns true if the polynomial has no non-trivial factors.
+func (p PolynomialOf[E]) IsIrreducible() bool {
+	if p.Degree() < 1 {
+		return false
+	}
+
+	factors := p.Factor()
+	return len(factors) == 1 && factors[0].Multiplicity == 1
+}
+
+// squareFree splits a monic polynomial into square-free polynomials, each paired with the multiplicity that its factors
+// have in p.
+func (p PolynomialOf[E]) squareFree() (out []FactorOf[E]) {
+	p = p.trim()
+	if p.Degree() < 1 {
+		return nil
+	}
+
+	d := p.Derivative()
+	if d.IsZero() {
+		// p is a square. Its square root has the square roots of the even coefficients of p.
+		root := make(PolynomialOf[E], p.Degree()/2+1)
+		for i, _ := range root {
+			root[i] = sqrt(p[2*i])
+		}
+
+		for _, f := range root.squareFree() {
+			out = append(out, FactorOf[E]{f.Poly, 2 * f.Multiplicity})
+		}
+
+		return
+	}
+
+	// Strip off the factors of p whose multiplicity is odd, one multiplicity at a time. What's left is a square.
+	g := p.GCD(d)
+	w, _ := p.DivMod(g)
+
+	for i := 1; !w.IsOne(); i++ {
+		y := w.GCD(g)
+		if z, _ := w.DivMod(y); !z.IsOne() {
+			out = append(out, FactorOf[E]{z.Monic(), i})
+		}
+
+		w = y
+		g, _ = g.DivMod(y)
+	}
+
+	for _, f := range g.squareFree() {
+		out = append(out, f)
+	}
+
+	return
+}
+
+// berlekamp returns the monic irreducible factors of a monic square-free polynomial with Berlekamp's algorithm.
+func (p PolynomialOf[E]) berlekamp() []PolynomialOf[E] {
+	n := p.Degree()
+	if n <= 1 {
+		return []PolynomialOf[E]{p.trim()}
+	}
+
+	// Find every v of degree less than n such that v^q = v (mod p), where q is the size of the field. Since c^q = c for
+	// every c in E, v^q is the sum of v_i x^(qi), so these are the fixed points of a linear map.
+	frob := frobenius(monomial(E(1), 1), p)
+
+	q := GenerateEmptyOf[E](n, n)
+	power := PolynomialOf[E]{1}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			q[i][j] = power.coefficient(j)
+		}
+		q[i][i] = q[i][i].Add(1)
+
+		power = power.Mul(frob).Mod(p)
+	}
+
+	// Every v splits each factor f into the gcds of f with v + c, for all c in E.
+	factors := []PolynomialOf[E]{p.trim()}
+	basis := q.Transpose().NullSpace()
+
+	for _, row := range basis {
+		v := PolynomialOf[E](row.Dup()).trim()
+		if v.Degree() < 1 {
+			continue
+		}
+
+		next := []PolynomialOf[E]{}
+		for _, f := range factors {
+			for c := 0; c < fieldSize[E]() && f.Degree() > 0; c++ {
+				a := f.GCD(v.Add(PolynomialOf[E]{E(c)}))
+				if a.Degree() < 1 {
+					continue
+				}
+
+				next = append(next, a)
+				f, _ = f.DivMod(a)
+			}
+
+			if f.Degree() > 0 {
+				next = append(next, f)
+			}
+		}
+
+		factors = next
+		if len(factors) == len(basis) {
+			break
+		}
+	}
+
+	return factors
+}
+
+// frobenius returns v^q mod f, where q is the size of the field.
+func frobenius[E Element[E]](v, f PolynomialOf[E]) PolynomialOf[E] {
+	for i := 0; i < fieldDegree[E](); i++ {
+		v = v.Mul(v).Mod(f)
+	}
+
+	return v
+}
+
+// sqrt returns the square root of a field element: x^(q/2), where q is the size of the field.
+func sqrt[E Element[E]](x E) E {
+	for i := 1; i < fieldDegree[E](); i++ {
+		x = x.Mul(x)
+	}
+
+	return x
+}
+
+// String converts the polynomial to a human-readable form, like "x^2 + 03*x + 01".
+func (p PolynomialOf[E]) String() string {
+	terms := []string{}
+
+	for i := p.Degree(); i >= 0; i-- {
+		if p[i].IsZero() {
+			continue
+		}
+
+		coeff := fmt.Sprintf("%2.2x", p[i])
+
+		switch {
+		case i == 0:
+			terms = append(terms, coeff)
+		case p[i].IsOne() && i == 1:
+			terms = append(terms, "x")
+		case p[i].IsOne():
+			terms = append(terms, fmt.Sprintf("x^%v", i))
+		case i == 1:
+			terms = append(terms, coeff+"*x")
+		default:
+			terms = append(terms, fmt.Sprintf("%v*x^%v", coeff, i))
+		}
+	}
+
+	if len(terms) == 0 {
+		return "0"
+	}
+
+	return strings.Join(terms, " + ")
+}
@@ -2,21 +2,57 @@ package gfmatrix
 
 import (
 	"fmt"
+	"math/bits"
 
 	"github.com/OpenWhiteBox/primitives/number"
 )
 
+// Element is an element of a binary field GF(2^n) with n <= 8, stored in the low n bits of a uint16. Every matrix in
+// this package is over some Element, like number.ByteFieldElem for Rijndael's field or number.FieldElem for any other.
+type Element[E any] interface {
+	~uint16
+
+	Add(E) E
+	Mul(E) E
+	Invert() E
+	IsZero() bool
+	IsOne() bool
+	Dup() E
+
+	// Modulus returns the irreducible polynomial defining the field.
+	Modulus() uint16
+}
+
+// fieldDegree returns the degree of the field E over GF(2).
+func fieldDegree[E Element[E]]() int {
+	var e E
+	return bits.Len16(e.Modulus()) - 1
+}
+
+// fieldSize returns the number of elements in the field E.
+func fieldSize[E Element[E]]() int {
+	return 1 << uint(fieldDegree[E]())
+}
+
+// RowOf is a row / vector of elements from the field E.
+type RowOf[E Element[E]] []E
+
 // Row is a row / vector of elements from GF(2^8).
-type Row []number.ByteFieldElem
+type Row = RowOf[number.ByteFieldElem]
 
 // NewRow returns an empty n-component row.
 func NewRow(n int) Row {
-	return Row(make([]number.ByteFieldElem, n))
+	return NewRowOf[number.ByteFieldElem](n)
+}
+
+// NewRowOf returns an empty n-component row over the field E.
+func NewRowOf[E Element[E]](n int) RowOf[E] {
+	return RowOf[E](make([]E, n))
 }
 
 // LessThan returns true if row i is "less than" row j. If you use sort a permutation matrix according to LessThan,
 // you'll always get the identity matrix.
-func LessThan(i, j Row) bool {
+func LessThan[E Element[E]](i, j RowOf[E]) bool {
 	if i.Size() != j.Size() {
 		panic("Can't compare rows that are different sizes!")
 	}
@@ -34,22 +70,33 @@ func LessThan(i, j Row) bool {
 	return false
 }
 
-// Add adds two vectors from GF(2^8)^n.
-func (e Row) Add(f Row) Row {
-	if e.Size() != f.Size() {
+// Add adds two vectors from GF(2^k)^n.
+func (e RowOf[E]) Add(f RowOf[E]) RowOf[E] {
+	out, err := e.CheckedAdd(f)
+	if err != nil {
 		panic("Can't add rows that are different sizes!")
 	}
 
+	return out
+}
+
+// CheckedAdd adds two vectors from GF(2^k)^n. It returns ErrDimensionMismatch instead of panicking if they're different
+// sizes.
+func (e RowOf[E]) CheckedAdd(f RowOf[E]) (RowOf[E], error) {
+	if e.Size() != f.Size() {
+		return nil, ErrDimensionMismatch
+	}
+
 	out := e.Dup()
 	for i, f_i := range f {
 		out[i] = out[i].Add(f_i)
 	}
 
-	return out
+	return out, nil
 }
 
 // ScalarMul multiplies a row by a scalar.
-func (e Row) ScalarMul(f number.ByteFieldElem) Row {
+func (e RowOf[E]) ScalarMul(f E) RowOf[E] {
 	out := e.Dup()
 	for i, _ := range out {
 		out[i] = out[i].Mul(f)
@@ -59,12 +106,12 @@ func (e Row) ScalarMul(f number.ByteFieldElem) Row {
 }
 
 // DotProduct computes the dot product of two vectors.
-func (e Row) DotProduct(f Row) number.ByteFieldElem {
+func (e RowOf[E]) DotProduct(f RowOf[E]) E {
 	if e.Size() != f.Size() {
 		panic("Can't compute dot product of two vectors of different sizes!")
 	}
 
-	res := number.ByteFieldElem(0x00)
+	res := E(0x00)
 	for i, _ := range e {
 		res = res.Add(e[i].Mul(f[i]))
 	}
@@ -72,9 +119,13 @@ func (e Row) DotProduct(f Row) number.ByteFieldElem {
 	return res
 }
 
-// IsPermutation returns true if the row is a permutation of the first len(e) elements of GF(2^8) and false otherwise.
-func (e Row) IsPermutation() bool {
-	sums := [256]int{}
+// IsPermutation returns true if the row is a permutation of the first len(e) elements of the field and false otherwise.
+func (e RowOf[E]) IsPermutation() bool {
+	if len(e) > fieldSize[E]() {
+		return false
+	}
+
+	sums := make([]int, fieldSize[E]())
 	for _, e_i := range e {
 		sums[e_i]++
 	}
@@ -89,7 +140,7 @@ func (e Row) IsPermutation() bool {
 }
 
 // Height returns the position of the first non-zero entry in the row, or -1 if the row is zero.
-func (e Row) Height() int {
+func (e RowOf[E]) Height() int {
 	for i, e_i := range e {
 		if !e_i.IsZero() {
 			return i
@@ -100,7 +151,7 @@ func (e Row) Height() int {
 }
 
 // Equals returns true if two rows are equal and false otherwise.
-func (e Row) Equals(f Row) bool {
+func (e RowOf[E]) Equals(f RowOf[E]) bool {
 	if e.Size() != f.Size() {
 		panic("Can't compare rows that are different sizes!")
 	}
@@ -115,7 +166,7 @@ func (e Row) Equals(f Row) bool {
 }
 
 // IsZero returns whether or not the row is identically zero.
-func (e Row) IsZero() bool {
+func (e RowOf[E]) IsZero() bool {
 	for _, e_i := range e {
 		if !e_i.IsZero() {
 			return false
@@ -126,28 +177,28 @@ func (e Row) IsZero() bool {
 }
 
 // Size returns the dimension of the vector.
-func (e Row) Size() int {
+func (e RowOf[E]) Size() int {
 	return len(e)
 }
 
 // Dup returns a duplicate of this row.
-func (e Row) Dup() Row {
-	out := NewRow(e.Size())
+func (e RowOf[E]) Dup() RowOf[E] {
+	out := NewRowOf[E](e.Size())
 	copy(out, e)
 
 	return out
 }
 
-func (e Row) String() string {
+func (e RowOf[E]) String() string {
 	out := []rune{}
-	out = append(out, []rune(fmt.Sprintf("%2.2x", []number.ByteFieldElem(e)))...)
+	out = append(out, []rune(fmt.Sprintf("%2.2x", []E(e)))...)
 	out = out[1 : len(out)-1]
 
 	return string(out)
 }
 
 // OctaveString converts the row into a string that can be imported into Octave.
-func (e Row) OctaveString() string {
+func (e RowOf[E]) OctaveString() string {
 	out := []rune{}
 
 	for _, elem := range e {
@@ -1,11 +1,11 @@
 package gfmatrix
 
 // RightStretch returns the matrix of right multiplication by the given matrix.
-func (e Matrix) RightStretch() Matrix {
+func (e MatrixOf[E]) RightStretch() MatrixOf[E] {
 	n, m := e.Size()
 	nm := n * m
 
-	out := GenerateEmpty(nm, nm)
+	out := GenerateEmptyOf[E](nm, nm)
 
 	for i := 0; i < nm; i++ {
 		p, q := i/n, i%n
@@ -19,11 +19,11 @@ func (e Matrix) RightStretch() Matrix {
 }
 
 // LeftStretch returns the matrix of left matrix multiplication by the given matrix.
-func (e Matrix) LeftStretch() Matrix {
+func (e MatrixOf[E]) LeftStretch() MatrixOf[E] {
 	n, m := e.Size()
 	nm := n * m
 
-	out := GenerateEmpty(nm, nm)
+	out := GenerateEmptyOf[E](nm, nm)
 
 	for i := 0; i < nm; i++ {
 		p, q := i/n, i%n
@@ -0,0 +1,42 @@
+// Package matching finds perfect matchings in bipartite graphs. It's shared by the matrix and gfmatrix packages.
+package matching
+
+// Perfect returns a perfect matching in the bipartite graph between n rows and n columns, with an edge between row i
+// and column j wherever edge(i, j) is true: row i is matched to column out[i]. It returns false if there isn't one. It
+// uses Kuhn's augmenting path algorithm.
+func Perfect(n int, edge func(i, j int) bool) (out []int, ok bool) {
+	match := make([]int, n) // The row matched to each column, or -1.
+	for j, _ := range match {
+		match[j] = -1
+	}
+
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for j := 0; j < n; j++ {
+			if seen[j] || !edge(i, j) {
+				continue
+			}
+
+			seen[j] = true
+			if match[j] == -1 || augment(match[j], seen) {
+				match[j] = i
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for i := 0; i < n; i++ {
+		if !augment(i, make([]bool, n)) {
+			return nil, false
+		}
+	}
+
+	out = make([]int, n)
+	for j, i := range match {
+		out[i] = j
+	}
+
+	return out, true
+}
@@ -0,0 +1,164 @@
+// Package parse splits matrices written by Octave, Go and Sage into rows of tokens. It's shared by the matrix and
+// gfmatrix packages, which parse the tokens into entries and wrap its errors with their own ErrMalformed.
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Octave splits an Octave matrix, like "[0 1; 1 0]" or one row per line, into rows of tokens.
+func Octave(s string) ([][]string, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "[") {
+		if !strings.HasSuffix(s, "]") {
+			return nil, errors.New("unbalanced brackets")
+		}
+
+		s = s[1 : len(s)-1]
+	}
+
+	rows := [][]string{}
+	for _, line := range strings.FieldsFunc(s, func(r rune) bool { return r == '\n' || r == ';' }) {
+		toks := strings.FieldsFunc(line, func(r rune) bool { return r == ' ' || r == '\t' || r == ',' || r == '\r' })
+		if len(toks) > 0 {
+			rows = append(rows, toks)
+		}
+	}
+
+	return rows, nil
+}
+
+// GoString splits a Go composite literal of rows into rows of tokens.
+func GoString(s string) ([][]string, error) {
+	start, end := strings.Index(s, "{"), strings.LastIndex(s, "}")
+	if start == -1 || end < start {
+		return nil, errors.New("no composite literal")
+	}
+
+	rows := [][]string{}
+	for rest := s[start+1 : end]; ; {
+		open := strings.Index(rest, "{")
+		if open == -1 {
+			if strings.Trim(rest, " \t\r\n,") != "" {
+				return nil, fmt.Errorf("junk %q after last row", rest)
+			}
+
+			return rows, nil
+		}
+
+		shut := strings.Index(rest, "}")
+		if shut < open {
+			return nil, errors.New("unbalanced braces")
+		}
+
+		toks := []string{}
+		for _, tok := range strings.Split(rest[open+1:shut], ",") {
+			if tok = strings.TrimSpace(tok); tok != "" {
+				toks = append(toks, tok)
+			}
+		}
+
+		rows, rest = append(rows, toks), rest[shut+1:]
+	}
+}
+
+// Sage splits a Sage matrix constructor into the field it's over and rows of tokens.
+func Sage(s string) (field string, rows [][]string, err error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "matrix(") || !strings.HasSuffix(s, ")") {
+		return "", nil, errors.New("not a call to matrix")
+	}
+
+	args, err := TopLevel(s[len("matrix(") : len(s)-1])
+	if err != nil {
+		return "", nil, err
+	} else if len(args) < 2 {
+		return "", nil, errors.New("matrix needs a field and entries")
+	}
+
+	field, dims, list := args[0], args[1:len(args)-1], args[len(args)-1]
+	if !strings.HasPrefix(list, "[") || !strings.HasSuffix(list, "]") {
+		return "", nil, errors.New("entries aren't a list")
+	}
+
+	entries, err := TopLevel(list[1 : len(list)-1])
+	if err != nil {
+		return "", nil, err
+	}
+
+	// A list of lists gives the rows directly.
+	if len(entries) > 0 && strings.HasPrefix(entries[0], "[") {
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry, "[") || !strings.HasSuffix(entry, "]") {
+				return "", nil, fmt.Errorf("%q isn't a row", entry)
+			}
+
+			row, err := TopLevel(entry[1 : len(entry)-1])
+			if err != nil {
+				return "", nil, err
+			}
+
+			rows = append(rows, row)
+		}
+
+		return field, rows, nil
+	}
+
+	// Otherwise, the number of rows (and maybe columns) comes first and the list is flat.
+	if len(dims) == 0 || len(dims) > 2 {
+		return "", nil, errors.New("flat list of entries needs dimensions")
+	}
+
+	n, err := strconv.Atoi(dims[0])
+	if err != nil || n <= 0 || len(entries)%n != 0 {
+		return "", nil, fmt.Errorf("bad number of rows %q", dims[0])
+	}
+
+	m := len(entries) / n
+	if len(dims) == 2 {
+		if m2, err := strconv.Atoi(dims[1]); err != nil || m2 != m {
+			return "", nil, fmt.Errorf("bad number of columns %q", dims[1])
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		rows = append(rows, entries[i*m:(i+1)*m])
+	}
+
+	return field, rows, nil
+}
+
+// TopLevel splits s at the commas that aren't inside any parentheses or brackets, and trims each piece.
+func TopLevel(s string) (out []string, err error) {
+	depth, start := 0, 0
+
+	for i, r := range s {
+		switch r {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+			if depth < 0 {
+				return nil, errors.New("unbalanced brackets")
+			}
+		case ',':
+			if depth == 0 {
+				out = append(out, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	if depth != 0 {
+		return nil, errors.New("unbalanced brackets")
+	}
+
+	if last := strings.TrimSpace(s[start:]); last != "" || len(out) > 0 {
+		out = append(out, last)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,126 @@
+// Package poly implements arithmetic on polynomials over a field. It's shared by the number and gfmatrix packages,
+// which store field elements differently: number.Field is a value that does arithmetic on uint64s, while gfmatrix's
+// elements do arithmetic on themselves.
+//
+// A polynomial is a slice where entry i is the coefficient of x^i. Every function returns a trimmed polynomial, so the
+// zero polynomial is empty, and none of them modify their arguments.
+package poly
+
+// Field is the arithmetic of the field that coefficients are in. The zero value of T has to be the field's zero.
+type Field[T comparable] interface {
+	Add(a, b T) T
+	Mul(a, b T) T
+	Invert(a T) T
+}
+
+// Trim removes leading zero coefficients.
+func Trim[T comparable](p []T) []T {
+	var zero T
+	for len(p) > 0 && p[len(p)-1] == zero {
+		p = p[:len(p)-1]
+	}
+
+	return p
+}
+
+// Add returns p + q.
+func Add[T comparable](f Field[T], p, q []T) []T {
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+
+	out := make([]T, len(p))
+	copy(out, p)
+
+	for i, q_i := range q {
+		out[i] = f.Add(out[i], q_i)
+	}
+
+	return Trim(out)
+}
+
+// Mul returns p * q.
+func Mul[T comparable](f Field[T], p, q []T) []T {
+	p, q = Trim(p), Trim(q)
+	if len(p) == 0 || len(q) == 0 {
+		return nil
+	}
+
+	out := make([]T, len(p)+len(q)-1)
+	for i, p_i := range p {
+		for j, q_j := range q {
+			out[i+j] = f.Add(out[i+j], f.Mul(p_i, q_j))
+		}
+	}
+
+	return Trim(out)
+}
+
+// ScalarMul returns c * p.
+func ScalarMul[T comparable](f Field[T], p []T, c T) []T {
+	out := make([]T, len(p))
+	for i, p_i := range p {
+		out[i] = f.Mul(p_i, c)
+	}
+
+	return Trim(out)
+}
+
+// DivMod returns the quotient and remainder of dividing p by q. It panics if q is zero.
+func DivMod[T comparable](f Field[T], p, q []T) (quo, rem []T) {
+	q = Trim(q)
+	if len(q) == 0 {
+		panic("Can't divide by zero polynomial!")
+	}
+
+	rem = Add(f, p, nil)
+	if len(rem) < len(q) {
+		return nil, rem
+	}
+
+	quo = make([]T, len(rem)-len(q)+1)
+	inv := f.Invert(q[len(q)-1])
+
+	for len(rem) >= len(q) {
+		c, shift := f.Mul(rem[len(rem)-1], inv), len(rem)-len(q)
+		quo[shift] = c
+
+		for j, q_j := range q {
+			rem[shift+j] = f.Add(rem[shift+j], f.Mul(q_j, c))
+		}
+		rem = Trim(rem)
+	}
+
+	return Trim(quo), rem
+}
+
+// MulMod returns p * q mod m.
+func MulMod[T comparable](f Field[T], p, q, m []T) []T {
+	_, rem := DivMod(f, Mul(f, p, q), m)
+	return rem
+}
+
+// Monic returns p divided by its leading coefficient. It panics if p is zero.
+func Monic[T comparable](f Field[T], p []T) []T {
+	p = Trim(p)
+	if len(p) == 0 {
+		panic("Can't make zero polynomial monic!")
+	}
+
+	return ScalarMul(f, p, f.Invert(p[len(p)-1]))
+}
+
+// GCD returns the monic greatest common divisor of p and q, or zero if both are zero.
+func GCD[T comparable](f Field[T], p, q []T) []T {
+	p, q = Trim(p), Trim(q)
+	for len(q) > 0 {
+		_, rem := DivMod(f, p, q)
+		p, q = q, rem
+	}
+
+	if len(p) == 0 {
+		return nil
+	}
+
+	return Monic(f, p)
+}
@@ -0,0 +1,64 @@
+package matrix
+
+import (
+	"io"
+)
+
+// Affine is an affine map on GF(2)^n: a linear transformation composed with an additive one, x -> Linear(x) + Constant.
+type Affine struct {
+	Linear   Matrix
+	Constant Row
+}
+
+// GenerateRandomAffine generates a random invertible affine map on GF(2)^n using the random source reader (for
+// example, crypto/rand.Reader).
+func GenerateRandomAffine(reader io.Reader, n int) Affine {
+	return Affine{
+		Linear:   GenerateRandom(reader, n),
+		Constant: GenerateRandomRow(reader, n),
+	}
+}
+
+// Apply returns the affine map applied to x.
+func (a Affine) Apply(x Row) Row {
+	return a.Linear.Mul(x).Add(a.Constant)
+}
+
+// Compose returns the result of composing a with b: the map x -> a(b(x)).
+func (a Affine) Compose(b Affine) Affine {
+	return Affine{
+		Linear:   a.Linear.Compose(b.Linear),
+		Constant: a.Apply(b.Constant),
+	}
+}
+
+// Invert computes the inverse of an affine map, if it exists.
+func (a Affine) Invert() (Affine, bool) {
+	inv, ok := a.Linear.Invert()
+	if !ok {
+		return Affine{}, false
+	}
+
+	return Affine{
+		Linear:   inv,
+		Constant: inv.Mul(a.Constant),
+	}, true
+}
+
+// Equals returns true if two affine maps are equal and false otherwise.
+func (a Affine) Equals(b Affine) bool {
+	return a.Linear.Equals(b.Linear) && a.Constant.Size() == b.Constant.Size() && a.Constant.Equals(b.Constant)
+}
+
+// Size returns the dimensions of the affine map's linear part in (Rows, Columns) order.
+func (a Affine) Size() (int, int) {
+	return a.Linear.Size()
+}
+
+// Dup returns a duplicate of this affine map.
+func (a Affine) Dup() Affine {
+	return Affine{
+		Linear:   a.Linear.Dup(),
+		Constant: a.Constant.Dup(),
+	}
+}
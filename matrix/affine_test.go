@@ -0,0 +1,32 @@
+package matrix
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestAffine(t *testing.T) {
+	a, b := GenerateRandomAffine(rand.Reader, 72), GenerateRandomAffine(rand.Reader, 72)
+	ab := a.Compose(b)
+
+	aInv, ok := a.Invert()
+	if !ok {
+		t.Fatal("Failed to invert invertible affine map.")
+	}
+
+	for i := 0; i < 100; i++ {
+		x := GenerateRandomRow(rand.Reader, 72)
+
+		if !ab.Apply(x).Equals(a.Apply(b.Apply(x))) {
+			t.Fatal("Composition of affine maps is wrong!")
+		} else if !aInv.Apply(a.Apply(x)).Equals(x) || !a.Apply(aInv.Apply(x)).Equals(x) {
+			t.Fatal("A * A^-1 != A^-1 * A != I")
+		}
+	}
+
+	if !aInv.Compose(a).Equals(Affine{GenerateIdentity(72), NewRow(72)}) {
+		t.Fatal("Composition of affine map with inverse isn't the identity.")
+	} else if a.Equals(b) {
+		t.Fatal("Two random affine maps were equal.")
+	}
+}
@@ -0,0 +1,183 @@
+package matrix
+
+import (
+	"math/big"
+)
+
+// AffineDeductiveMatrix is the affine analogue of DeductiveMatrix: it allows the incremental deduction of an invertible
+// affine map x -> A(x) + c from (input, output) pairs.
+//
+// The first pair asserted is taken as an origin, (x0, y0). Every later pair (x, y) is then the linear assertion that
+// A(x + x0) = y + y0, and once A is fully defined, the constant is c = y0 + A(x0). Equivalently, the map is fully
+// defined after n+1 affinely independent inputs are known.
+type AffineDeductiveMatrix struct {
+	Linear *DeductiveMatrix
+
+	origin    bool // Whether an origin has been asserted yet.
+	originIn  Row
+	originOut Row
+}
+
+// AffineDeductiveMarker identifies a point in the history of an affine deductive matrix that it can be rolled back to.
+type AffineDeductiveMarker struct {
+	linear DeductiveMarker
+	origin bool
+}
+
+// NewAffineDeductiveMatrix returns a new affine deductive matrix on GF(2)^n.
+func NewAffineDeductiveMatrix(n int) *AffineDeductiveMatrix {
+	return &AffineDeductiveMatrix{
+		Linear: NewDeductiveMatrix(n),
+	}
+}
+
+// Assert represents an assertion that A(in) + c = out. The function will panic if this is inconsistent with previous
+// assertions. It it's not, it returns whether or not the assertion contained new information about the map.
+func (adm *AffineDeductiveMatrix) Assert(in, out Row) (learned bool) {
+	learned, err := adm.CheckedAssert(in, out)
+	if err == ErrInconsistentAssertion {
+		panic("Asserted input, output pair is inconsistent with previous assertions!")
+	} else if err != nil {
+		panic("Tried to reduce incorrectly sized row with incremental matrix!")
+	}
+
+	return learned
+}
+
+// CheckedAssert represents an assertion that A(in) + c = out. It returns ErrInconsistentAssertion if this is
+// inconsistent with previous assertions and ErrDimensionMismatch if either row is the wrong size; the map is left
+// unchanged in both cases. Otherwise, it returns whether or not the assertion contained new information about the map.
+func (adm *AffineDeductiveMatrix) CheckedAssert(in, out Row) (learned bool, err error) {
+	if in.Size() != adm.Linear.Input.n || out.Size() != adm.Linear.Output.n {
+		return false, ErrDimensionMismatch
+	}
+
+	if !adm.origin {
+		adm.origin, adm.originIn, adm.originOut = true, in.Dup(), out.Dup()
+		return true, nil
+	}
+
+	return adm.Linear.CheckedAssert(in.Add(adm.originIn), out.Add(adm.originOut))
+}
+
+// FullyDefined returns true if the assertions made give a fully defined affine map.
+func (adm *AffineDeductiveMatrix) FullyDefined() bool {
+	return adm.origin && adm.Linear.FullyDefined()
+}
+
+// Constant returns the deduced map's constant, A(0) + c = c. It's known as soon as 0 is in the affine span of the
+// asserted inputs, which may be before the map is fully defined. Returns nil if it isn't known yet.
+func (adm *AffineDeductiveMatrix) Constant() Row {
+	if !adm.origin {
+		return nil
+	}
+
+	// 0 is in the affine span exactly when x0 is in the linear span of the differences.
+	reduced, inverse := adm.Linear.Input.reduce(adm.originIn)
+	if !reduced.IsZero() {
+		return nil
+	}
+
+	return adm.Linear.Output.Matrix().Transpose().Mul(inverse).Add(adm.originOut)
+}
+
+// Affine returns the deduced affine map. Returns the zero value if it isn't fully defined yet.
+func (adm *AffineDeductiveMatrix) Affine() Affine {
+	if !adm.FullyDefined() {
+		return Affine{}
+	}
+
+	linear := adm.Linear.Matrix()
+
+	return Affine{
+		Linear:   linear,
+		Constant: linear.Mul(adm.originIn).Add(adm.originOut),
+	}
+}
+
+// Inverse returns the deduced affine map's inverse. Returns the zero value if it isn't fully defined yet.
+func (adm *AffineDeductiveMatrix) Inverse() Affine {
+	if !adm.FullyDefined() {
+		return Affine{}
+	}
+
+	inverse := adm.Linear.Inverse()
+
+	return Affine{
+		Linear:   inverse,
+		Constant: inverse.Mul(adm.originOut).Add(adm.originIn),
+	}
+}
+
+// NovelSize returns the number of inputs that are NOT in the affine span of the known inputs, which is the same as the
+// number of outputs that are NOT in the affine span of the known outputs. It can be as large as 2^n, so it's a big.Int.
+func (adm *AffineDeductiveMatrix) NovelSize() *big.Int {
+	size := adm.Linear.Input.novelSize()
+	if !adm.origin {
+		size.Add(size, big.NewInt(1))
+	}
+
+	return size
+}
+
+// NovelInput returns the nth input that is NOT in the affine span of the known inputs. n will be considered modulo
+// adm.NovelSize().
+func (adm *AffineDeductiveMatrix) NovelInput(n int) Row {
+	return adm.novel(&adm.Linear.Input, adm.originIn, n)
+}
+
+// NovelOutput returns the nth output that is NOT in the affine span of the known outputs. n will be considered modulo
+// adm.NovelSize().
+func (adm *AffineDeductiveMatrix) NovelOutput(n int) Row {
+	return adm.novel(&adm.Linear.Output, adm.originOut, n)
+}
+
+// novel translates the novel rows of an incremental matrix by the origin. Without an origin, every row is novel, so
+// the zero row comes first and is followed by the novel rows of the (empty) incremental matrix.
+func (adm *AffineDeductiveMatrix) novel(im *IncrementalMatrix, origin Row, n int) Row {
+	if adm.FullyDefined() {
+		return nil
+	}
+
+	k := new(big.Int).Mod(big.NewInt(int64(n)), adm.NovelSize())
+
+	if !adm.origin {
+		if k.Sign() == 0 {
+			return NewRow(im.n)
+		}
+
+		return im.novelRow(k.Sub(k, big.NewInt(1)))
+	}
+
+	return im.novelRow(k).Add(origin)
+}
+
+// Checkpoint returns a marker for the current state of the affine deductive matrix, which can be passed to Rollback to
+// undo every assertion made after it.
+func (adm *AffineDeductiveMatrix) Checkpoint() AffineDeductiveMarker {
+	return AffineDeductiveMarker{adm.Linear.Checkpoint(), adm.origin}
+}
+
+// Rollback undoes every assertion made since the given marker was returned by Checkpoint.
+func (adm *AffineDeductiveMatrix) Rollback(marker AffineDeductiveMarker) {
+	adm.Linear.Rollback(marker.linear)
+
+	if !marker.origin {
+		adm.origin, adm.originIn, adm.originOut = false, nil, nil
+	}
+}
+
+// Commit keeps every assertion made since the given marker was returned by Checkpoint.
+func (adm *AffineDeductiveMatrix) Commit(marker AffineDeductiveMarker) {
+	adm.Linear.Commit(marker.linear)
+}
+
+// Dup returns a duplicate of adm.
+func (adm *AffineDeductiveMatrix) Dup() *AffineDeductiveMatrix {
+	return &AffineDeductiveMatrix{
+		Linear:    adm.Linear.Dup(),
+		origin:    adm.origin,
+		originIn:  adm.originIn.Dup(),
+		originOut: adm.originOut.Dup(),
+	}
+}
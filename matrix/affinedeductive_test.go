@@ -0,0 +1,162 @@
+package matrix
+
+import (
+	"math/big"
+	"testing"
+
+	"crypto/rand"
+)
+
+func TestAffineDeductiveMatrix(t *testing.T) {
+	adm := NewAffineDeductiveMatrix(64)
+	a := GenerateRandomAffine(rand.Reader, 64)
+	aInv, _ := a.Invert()
+
+	assertions := 0
+	for !adm.FullyDefined() {
+		in := GenerateRandomRow(rand.Reader, 64)
+		adm.Assert(in, a.Apply(in))
+		assertions++
+	}
+
+	t.Logf("Took %v assertions to deduce 64-bit affine map.", assertions)
+
+	if !adm.Affine().Equals(a) {
+		t.Fatalf("Deduced affine map is wrong.")
+	} else if !adm.Inverse().Equals(aInv) {
+		t.Fatalf("Deduced affine map's inverse is wrong.")
+	} else if !adm.Constant().Equals(a.Constant) {
+		t.Fatalf("Deduced constant is wrong.")
+	}
+}
+
+func TestAffineDeductiveMatrixConstant(t *testing.T) {
+	adm := NewAffineDeductiveMatrix(64)
+	a := GenerateRandomAffine(rand.Reader, 64)
+
+	x, y := GenerateRandomRow(rand.Reader, 64), GenerateRandomRow(rand.Reader, 64)
+	adm.Assert(x, a.Apply(x))
+	adm.Assert(y, a.Apply(y))
+
+	if adm.Constant() != nil {
+		t.Fatalf("Constant was known before 0 was in the affine span.")
+	}
+
+	// 0 = x + y + (x + y), so the constant is known once x + y is.
+	adm.Assert(x.Add(y), a.Apply(x.Add(y)))
+
+	if c := adm.Constant(); c == nil || !c.Equals(a.Constant) {
+		t.Fatalf("Constant is wrong once 0 is in the affine span.")
+	} else if adm.FullyDefined() {
+		t.Fatalf("FullyDefined returned true after three assertions.")
+	}
+}
+
+func TestAffineDeductiveMatrixInconsistent(t *testing.T) {
+	adm := NewAffineDeductiveMatrix(64)
+	a := GenerateRandomAffine(rand.Reader, 64)
+
+	x, y := GenerateRandomRow(rand.Reader, 64), GenerateRandomRow(rand.Reader, 64)
+	adm.Assert(x, a.Apply(x))
+	adm.Assert(y, a.Apply(y))
+
+	// Affine maps preserve sums of an odd number of points, so x + y + z -> A(x) + A(y) + A(z) is implied.
+	z := GenerateRandomRow(rand.Reader, 64)
+	adm.Assert(z, a.Apply(z))
+
+	w := x.Add(y).Add(z)
+	if learned, err := adm.CheckedAssert(w, a.Apply(w)); learned || err != nil {
+		t.Fatalf("CheckedAssert learned from or rejected an implied pair: %v, %v", learned, err)
+	}
+
+	bad := a.Apply(w)
+	bad.SetBit(0, bad.GetBit(0) == 0)
+
+	if _, err := adm.CheckedAssert(w, bad); err != ErrInconsistentAssertion {
+		t.Fatalf("CheckedAssert didn't reject an inconsistent pair: %v", err)
+	}
+}
+
+func TestAffineDeductiveMatrixNovel(t *testing.T) {
+	adm := NewAffineDeductiveMatrix(8)
+	a := GenerateRandomAffine(rand.Reader, 8)
+
+	if adm.NovelSize().Cmp(big.NewInt(256)) != 0 {
+		t.Fatalf("Every point should be novel before any assertions, but NovelSize is %v.", adm.NovelSize())
+	}
+
+	for !adm.FullyDefined() {
+		seen := map[byte]bool{}
+
+		for n := 0; n < int(adm.NovelSize().Int64()); n++ {
+			in := adm.NovelInput(n)
+			if seen[in[0]] {
+				t.Fatalf("NovelInput repeated an input.")
+			}
+			seen[in[0]] = true
+
+			if learned, err := adm.Dup().CheckedAssert(in, a.Apply(in)); !learned || err != nil {
+				t.Fatalf("NovelInput returned an input that wasn't novel.")
+			}
+		}
+
+		in := adm.NovelInput(0)
+		adm.Assert(in, a.Apply(in))
+	}
+
+	if !adm.Affine().Equals(a) {
+		t.Fatalf("Deduced affine map is wrong.")
+	}
+}
+
+func TestAffineDeductiveMatrixRollback(t *testing.T) {
+	adm := NewAffineDeductiveMatrix(64)
+	a := GenerateRandomAffine(rand.Reader, 64)
+	mark := adm.Checkpoint()
+
+	for !adm.FullyDefined() {
+		in := GenerateRandomRow(rand.Reader, 64)
+		adm.Assert(in, a.Apply(in))
+	}
+	adm.Rollback(mark)
+
+	if adm.NovelSize().Cmp(new(big.Int).Lsh(big.NewInt(1), 64)) != 0 || adm.Constant() != nil {
+		t.Fatalf("Rollback didn't forget the origin.")
+	}
+}
+
+func TestAffineDeductiveMatrixNovelLarge(t *testing.T) {
+	adm := NewAffineDeductiveMatrix(64)
+	a := GenerateRandomAffine(rand.Reader, 64)
+
+	if in := adm.NovelInput(0); !in.IsZero() {
+		t.Fatalf("NovelInput(0) should be zero before any assertions.")
+	} else if in, out := adm.NovelInput(5), adm.NovelOutput(5); in.IsZero() || out.IsZero() {
+		t.Fatalf("NovelInput(5) and NovelOutput(5) should be non-zero before any assertions.")
+	}
+
+	for i := 0; i < 10; i++ {
+		in := adm.NovelInput(i)
+		if learned := adm.Assert(in, a.Apply(in)); !learned {
+			t.Fatalf("NovelInput(%v) returned an input that wasn't novel.", i)
+		}
+	}
+
+	// The first input is the origin, so the other 9 span a 9-dimensional space, and 2^9 * (2^55 - 1) inputs are left.
+	size := new(big.Int).Lsh(big.NewInt(1), 55)
+	size.Sub(size, big.NewInt(1)).Lsh(size, 9)
+	if adm.NovelSize().Cmp(size) != 0 {
+		t.Fatalf("NovelSize is %v, not %v.", adm.NovelSize(), size)
+	}
+
+	seen := map[string]bool{}
+	for _, n := range []int{0, 1, 1 << 20, 1 << 40, 1<<63 - 1} {
+		in := adm.NovelInput(n)
+		if seen[string(in)] {
+			t.Fatalf("NovelInput(%v) repeated an input.", n)
+		} else if learned, err := adm.Dup().CheckedAssert(in, a.Apply(in)); !learned || err != nil {
+			t.Fatalf("NovelInput(%v) returned an input that wasn't novel.", n)
+		}
+		seen[string(in)] = true
+	}
+}
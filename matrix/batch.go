@@ -0,0 +1,177 @@
+package matrix
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"runtime"
+	"sync"
+)
+
+// MulBatch right-multiplies the matrix by every row in src and writes the results to dst, so that dst[i] = e.Mul(src[i]).
+// Entries of dst that are nil are allocated.
+//
+// Rows are processed 64 at a time in bitsliced form: they're transposed so that each word holds one bit of 64 rows, and
+// each output bit then costs one XOR per non-zero entry in its row of the matrix.
+func (e Matrix) MulBatch(dst, src [][]byte) {
+	e.prepareBatch(dst, src)
+	newBatch(e).run(dst, src)
+}
+
+// MulBatchParallel is MulBatch, but splits the batch between the given number of goroutines. Zero or less means one
+// per CPU.
+func (e Matrix) MulBatchParallel(dst, src [][]byte, workers int) {
+	e.prepareBatch(dst, src)
+
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	b := newBatch(e)
+
+	// Give each goroutine a whole number of 64-row chunks.
+	per := (len(src)/workers + 63) / 64 * 64
+	if per == 0 {
+		per = 64
+	}
+
+	wg := sync.WaitGroup{}
+	for start := 0; start < len(src); start += per {
+		end := start + per
+		if end > len(src) {
+			end = len(src)
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			b.run(dst[start:end], src[start:end])
+		}(start, end)
+	}
+
+	wg.Wait()
+}
+
+// prepareBatch checks that src and dst are the right size for a batch multiplication and allocates missing rows of dst.
+func (e Matrix) prepareBatch(dst, src [][]byte) {
+	out, in := e.Size()
+	if len(dst) != len(src) {
+		panic("Can't multiply batches of different lengths!")
+	}
+
+	for i, row := range src {
+		if len(row) != rowsToColumns(in) {
+			panic("Can't multiply by row that is wrong size!")
+		}
+
+		if dst[i] == nil {
+			dst[i] = make([]byte, rowsToColumns(out))
+		} else if len(dst[i]) != rowsToColumns(out) {
+			panic("Can't write product to row that is wrong size!")
+		}
+	}
+}
+
+// batch is a matrix prepared for bitsliced multiplication.
+type batch struct {
+	out, in int
+	cols    [][]int // The positions of the non-zero entries in each row of the matrix.
+}
+
+func newBatch(e Matrix) *batch {
+	out, in := e.Size()
+	b := &batch{out: out, in: in, cols: make([][]int, out)}
+
+	for i, row := range e {
+		for k, x := range row {
+			for ; x != 0; x &= x - 1 {
+				b.cols[i] = append(b.cols[i], 8*k+bits.TrailingZeros8(x))
+			}
+		}
+	}
+
+	return b
+}
+
+// run multiplies every row in src by the matrix, writing the results to dst.
+func (b *batch) run(dst, src [][]byte) {
+	inStride, outStride := (b.in+63)/64, (b.out+63)/64
+
+	in, out := make([]uint64, 64*inStride), make([]uint64, 64*outStride)
+	block := [64]uint64{}
+
+	for start := 0; start < len(src); start += 64 {
+		k := len(src) - start
+		if k > 64 {
+			k = 64
+		}
+
+		// Bitslice the inputs: in[j] holds bit j of each of the k rows.
+		for w := 0; w < inStride; w++ {
+			for t := 0; t < 64; t++ {
+				if t < k {
+					block[t] = loadWord(src[start+t], w)
+				} else {
+					block[t] = 0
+				}
+			}
+
+			transpose64(&block)
+			copy(in[64*w:], block[:])
+		}
+
+		for i, cols := range b.cols {
+			acc := uint64(0)
+			for _, j := range cols {
+				acc ^= in[j]
+			}
+
+			out[i] = acc
+		}
+
+		// Un-bitslice the outputs.
+		for w := 0; w < outStride; w++ {
+			copy(block[:], out[64*w:])
+			transpose64(&block)
+
+			for t := 0; t < k; t++ {
+				storeWord(dst[start+t], w, block[t])
+			}
+		}
+	}
+}
+
+// loadWord returns the wth 64-bit word of row, padded with zeros.
+func loadWord(row []byte, w int) uint64 {
+	if 8*w+8 <= len(row) {
+		return binary.LittleEndian.Uint64(row[8*w:])
+	}
+
+	buf := [8]byte{}
+	copy(buf[:], row[8*w:])
+
+	return binary.LittleEndian.Uint64(buf[:])
+}
+
+// storeWord writes x to the wth 64-bit word of row, dropping whatever goes past its end.
+func storeWord(row []byte, w int, x uint64) {
+	if 8*w+8 <= len(row) {
+		binary.LittleEndian.PutUint64(row[8*w:], x)
+		return
+	}
+
+	buf := [8]byte{}
+	binary.LittleEndian.PutUint64(buf[:], x)
+	copy(row[8*w:], buf[:])
+}
+
+// transpose64 transposes a 64-by-64 bit matrix in place, where bit j of a[i] is the entry in row i and column j. It
+// swaps the off-diagonal blocks of each size from 32 down to 1.
+func transpose64(a *[64]uint64) {
+	for j, m := uint(32), uint64(0x00000000ffffffff); j != 0; j, m = j>>1, m^(m<<(j>>1)) {
+		for k := 0; k < 64; k = (k + int(j) + 1) &^ int(j) {
+			t := (a[k]>>j ^ a[k+int(j)]) & m
+			a[k] ^= t << j
+			a[k+int(j)] ^= t
+		}
+	}
+}
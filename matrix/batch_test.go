@@ -0,0 +1,66 @@
+package matrix
+
+import (
+	"testing"
+
+	"crypto/rand"
+)
+
+func TestTranspose64(t *testing.T) {
+	a, b := [64]uint64{}, [64]uint64{}
+	for i, _ := range a {
+		a[i] = uint64(i)*0x9e3779b97f4a7c15 ^ uint64(i)<<40
+	}
+
+	b = a
+	transpose64(&b)
+
+	for i := 0; i < 64; i++ {
+		for j := 0; j < 64; j++ {
+			if (a[i]>>uint(j))&1 != (b[j]>>uint(i))&1 {
+				t.Fatalf("Entry (%v, %v) wasn't transposed.", i, j)
+			}
+		}
+	}
+}
+
+func TestMulBatch(t *testing.T) {
+	for _, size := range [][2]int{{128, 128}, {8, 8}, {24, 72}, {136, 64}} {
+		m := GenerateEmpty(size[0], size[1])
+		for i, _ := range m {
+			m[i] = GenerateRandomRow(rand.Reader, size[1])
+		}
+
+		// Not a multiple of 64, so the last chunk is partial.
+		src, dst, par := make([][]byte, 1000), make([][]byte, 1000), make([][]byte, 1000)
+		for i, _ := range src {
+			src[i] = GenerateRandomRow(rand.Reader, size[1])
+		}
+
+		m.MulBatch(dst, src)
+		m.MulBatchParallel(par, src, 3)
+
+		for i, x := range src {
+			if real := m.Mul(Row(x)); !real.Equals(dst[i]) {
+				t.Fatalf("MulBatch on %v-by-%v matrix disagrees with Mul at %v.", size[0], size[1], i)
+			} else if !real.Equals(par[i]) {
+				t.Fatalf("MulBatchParallel on %v-by-%v matrix disagrees with Mul at %v.", size[0], size[1], i)
+			}
+		}
+	}
+}
+
+func BenchmarkMulBatch(b *testing.B) {
+	m := GenerateRandom(rand.Reader, 128)
+
+	src, dst := make([][]byte, 4096), make([][]byte, 4096)
+	for i, _ := range src {
+		src[i] = GenerateRandomRow(rand.Reader, 128)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.MulBatch(dst, src)
+	}
+}
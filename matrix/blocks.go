@@ -0,0 +1,110 @@
+package matrix
+
+// Blocks splits the matrix into a grid of size-by-size blocks. blocks[i][j] is the submatrix on rows size*i through
+// size*(i+1)-1 and columns size*j through size*(j+1)-1, so it's the part of output block i that depends on input block
+// j.
+func (e Matrix) Blocks(size int) (blocks [][]Matrix) {
+	out, in := e.Size()
+	if size <= 0 || out%size != 0 || in%size != 0 {
+		panic("Can't split matrix into blocks of that size!")
+	}
+
+	w := e.pack()
+	blocks = make([][]Matrix, out/size)
+
+	for i, _ := range blocks {
+		blocks[i] = make([]Matrix, in/size)
+
+		for j, _ := range blocks[i] {
+			block := GenerateEmpty(size, size)
+			for r, row := range block {
+				unpackRow(row, w.row(size*i+r), size*j, size)
+			}
+
+			blocks[i][j] = block
+		}
+	}
+
+	return
+}
+
+// AssembleBlocks is the inverse of Blocks: it joins a grid of size-by-size blocks into one matrix. The size is given
+// explicitly because rows are stored in whole bytes, so a block's width can't be recovered from the block itself.
+func AssembleBlocks(blocks [][]Matrix, size int) Matrix {
+	if len(blocks) == 0 {
+		return Matrix{}
+	}
+
+	out, in := size*len(blocks), size*len(blocks[0])
+	w := newWords(out, in)
+
+	for i, blockRow := range blocks {
+		if len(blockRow) != len(blocks[0]) {
+			panic("Can't assemble grid with rows of different lengths!")
+		}
+
+		for j, block := range blockRow {
+			if len(block) != size {
+				panic("Can't assemble block that is wrong size!")
+			}
+
+			for r, row := range block {
+				if row.Size() != rowsToColumns(size)*8 {
+					panic("Can't assemble block that is wrong size!")
+				}
+
+				// Only copy the first size bits, in case the row has junk past its end.
+				masked := row.Dup()
+				if size%8 != 0 {
+					masked[len(masked)-1] &= byte(1<<uint(size%8)) - 1
+				}
+
+				packRow(w.row(size*i+r), masked, size*j)
+			}
+		}
+	}
+
+	return w.unpack(0, in)
+}
+
+// Dependencies returns the zero pattern of the matrix split into size-by-size blocks, as a bitmap. The bit in row i and
+// column j is set if block (i, j) is non-zero, meaning that output block i depends on input block j. With size 8, this
+// tells which output bytes depend on which input bytes.
+func (e Matrix) Dependencies(size int) Matrix {
+	blocks := e.Blocks(size)
+	if len(blocks) == 0 {
+		return Matrix{}
+	}
+
+	out := GenerateEmpty(len(blocks), len(blocks[0]))
+
+	for i, blockRow := range blocks {
+		for j, block := range blockRow {
+			for _, row := range block {
+				if !row.IsZero() {
+					out[i].SetBit(j, true)
+					break
+				}
+			}
+		}
+	}
+
+	return out
+}
+
+// BlockRanks returns the rank of every block of the matrix split into size-by-size blocks. A block is invertible
+// exactly when its rank is size.
+func (e Matrix) BlockRanks(size int) (ranks [][]int) {
+	blocks := e.Blocks(size)
+	ranks = make([][]int, len(blocks))
+
+	for i, blockRow := range blocks {
+		ranks[i] = make([]int, len(blockRow))
+
+		for j, block := range blockRow {
+			ranks[i][j] = block.Rank()
+		}
+	}
+
+	return
+}
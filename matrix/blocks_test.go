@@ -0,0 +1,34 @@
+package matrix
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestBlocks(t *testing.T) {
+	m := GenerateTrueRandom(rand.Reader, 128)
+
+	for _, size := range []int{4, 8, 32} {
+		if !AssembleBlocks(m.Blocks(size), size).Equals(m) {
+			t.Fatalf("Splitting into %v-bit blocks and reassembling didn't give the original matrix.", size)
+		}
+	}
+
+	// A block-diagonal matrix with an invertible and a singular block.
+	a, b := GenerateRandom(rand.Reader, 12), GenerateTrueRandom(rand.Reader, 12)
+	b[5] = b[0].Add(b[1])
+
+	m = AssembleBlocks([][]Matrix{
+		{a, GenerateEmpty(12, 12)},
+		{GenerateEmpty(12, 12), b},
+	}, 12)
+
+	if !m.Dependencies(12).Equals(GenerateIdentity(2)) {
+		t.Fatalf("Dependencies of block-diagonal matrix are wrong:\n%v", m.Dependencies(12))
+	}
+
+	ranks := m.BlockRanks(12)
+	if ranks[0][0] != 12 || ranks[0][1] != 0 || ranks[1][0] != 0 || ranks[1][1] >= 12 {
+		t.Fatalf("Block ranks of block-diagonal matrix are wrong: %v", ranks)
+	}
+}
@@ -0,0 +1,196 @@
+package matrix
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+// BranchOptions configures a branch number computation. The zero value computes the exact branch number in one
+// goroutine.
+type BranchOptions struct {
+	// Bound, if non-zero, stops the search as soon as the branch number is known to be less than Bound. The number
+	// returned is then an upper bound on the branch number that's less than Bound, so comparing it to Bound is exact.
+	Bound int
+	// Workers is the number of goroutines to search with. Zero means one.
+	Workers int
+}
+
+// DifferentialBranchNumber returns the differential branch number of the matrix: the minimum of wt(x) + wt(M x) over
+// all non-zero x, where wt is the number of non-zero cells in a vector split into cells of the given width in bits (4 or
+// 8).
+//
+// Inputs are searched in order of increasing weight, and the search stops as soon as no heavier input could give a
+// smaller branch number. A 16-cell matrix with a high branch number is still expensive, so the search can be cut short
+// with opts.Bound and split across goroutines with opts.Workers.
+func (e Matrix) DifferentialBranchNumber(cell int, opts BranchOptions) int {
+	out, in := e.Size()
+	if cell != 4 && cell != 8 || in%cell != 0 || out%cell != 0 {
+		panic("Can't split matrix into cells of that width!")
+	}
+
+	bs := &branchSearch{
+		cells:  in / cell,
+		values: 1 << uint(cell),
+		stride: (out + 63) / 64,
+		opts:   opts,
+		best:   math.MaxInt32,
+	}
+
+	if cell == 4 {
+		bs.weight = nibbleWeight
+	} else {
+		bs.weight = byteWeight
+	}
+
+	// An injective matrix never sends a non-zero input to zero.
+	if e.Rank() == in {
+		bs.minOut = 1
+	}
+
+	// table[p][v] is the output of the matrix on the input that's v in cell p and zero everywhere else.
+	cols := e.Transpose().pack()
+	bs.table = make([]uint64, bs.cells*bs.values*bs.stride)
+
+	for p := 0; p < bs.cells; p++ {
+		for v := 1; v < bs.values; v++ {
+			dst, prev := bs.entry(p, v), bs.entry(p, v&(v-1))
+			src := cols.row(p*cell + bits.TrailingZeros(uint(v)))
+
+			for j := range dst {
+				dst[j] = prev[j] ^ src[j]
+			}
+		}
+	}
+
+	return bs.run()
+}
+
+// LinearBranchNumber returns the linear branch number of the matrix: the minimum of wt(x) + wt(M^T x) over all non-zero
+// x. See DifferentialBranchNumber.
+func (e Matrix) LinearBranchNumber(cell int, opts BranchOptions) int {
+	return e.Transpose().DifferentialBranchNumber(cell, opts)
+}
+
+// byteWeight returns the number of non-zero bytes in x.
+func byteWeight(x []uint64) (w int) {
+	for _, y := range x {
+		y |= y >> 4
+		y |= y >> 2
+		y |= y >> 1
+		w += bits.OnesCount64(y & 0x0101010101010101)
+	}
+
+	return
+}
+
+// nibbleWeight returns the number of non-zero nibbles in x.
+func nibbleWeight(x []uint64) (w int) {
+	for _, y := range x {
+		y |= y >> 2
+		y |= y >> 1
+		w += bits.OnesCount64(y & 0x1111111111111111)
+	}
+
+	return
+}
+
+// branchSearch holds the state of a branch number computation that's shared between workers.
+type branchSearch struct {
+	cells, values, stride int
+	table                 []uint64
+	weight                func([]uint64) int
+	minOut                int // A lower bound on the weight of the output of a non-zero input.
+
+	opts BranchOptions
+	best int64 // The smallest weight found so far. Accessed atomically.
+}
+
+// entry returns the output of the matrix on the input that's v in cell p.
+func (bs *branchSearch) entry(p, v int) []uint64 {
+	return bs.table[(p*bs.values+v)*bs.stride : (p*bs.values+v+1)*bs.stride]
+}
+
+// done returns true if no input of weight w or more can change the answer.
+func (bs *branchSearch) done(w int) bool {
+	best := int(atomic.LoadInt64(&bs.best))
+	return w+bs.minOut >= best || bs.opts.Bound > 0 && best < bs.opts.Bound
+}
+
+// update records that an input-output pair of total weight w was found.
+func (bs *branchSearch) update(w int) {
+	for {
+		best := atomic.LoadInt64(&bs.best)
+		if int64(w) >= best || atomic.CompareAndSwapInt64(&bs.best, best, int64(w)) {
+			return
+		}
+	}
+}
+
+// run searches inputs of each weight in turn, splitting each weight between workers by the first non-zero cell and its
+// value.
+func (bs *branchSearch) run() int {
+	workers := bs.opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	for w := 1; w <= bs.cells && !bs.done(w); w++ {
+		work := make(chan [2]int)
+		wg := sync.WaitGroup{}
+
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				acc := make([][]uint64, w)
+				for k := range acc {
+					acc[k] = make([]uint64, bs.stride)
+				}
+
+				for item := range work {
+					copy(acc[0], bs.entry(item[0], item[1]))
+					bs.search(w, 1, item[0]+1, acc)
+				}
+			}()
+		}
+
+		for p := 0; p <= bs.cells-w; p++ {
+			for v := 1; v < bs.values && !bs.done(w); v++ {
+				work <- [2]int{p, v}
+			}
+		}
+
+		close(work)
+		wg.Wait()
+	}
+
+	return int(bs.best)
+}
+
+// search enumerates every input of weight w whose first depth cells have been chosen and accumulated into acc, with
+// the remaining cells at position from or later.
+func (bs *branchSearch) search(w, depth, from int, acc [][]uint64) {
+	if depth == w {
+		bs.update(w + bs.weight(acc[depth-1]))
+		return
+	}
+
+	for p := from; p <= bs.cells-(w-depth); p++ {
+		if bs.done(w) {
+			return
+		}
+
+		for v := 1; v < bs.values; v++ {
+			prev, next, src := acc[depth-1], acc[depth], bs.entry(p, v)
+			for j := range next {
+				next[j] = prev[j] ^ src[j]
+			}
+
+			bs.search(w, depth+1, p+1, acc)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package matrix
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/OpenWhiteBox/primitives/number"
+)
+
+// mixColumns returns AES's MixColumns as a 32-by-32 binary matrix.
+func mixColumns() Matrix {
+	circ := []number.ByteFieldElem{2, 3, 1, 1}
+	m := GenerateEmpty(32, 32)
+
+	for col := 0; col < 32; col++ {
+		x := number.ByteFieldElem(1 << uint(col%8))
+
+		for i := 0; i < 4; i++ {
+			y := circ[(col/8-i+4)%4].Mul(x)
+
+			for j := 0; j < 8; j++ {
+				m[8*i+j].SetBit(col, y>>uint(j)&1 == 1)
+			}
+		}
+	}
+
+	return m
+}
+
+// nibbleBranch computes the differential branch number of a 16-by-16 matrix with 4-bit cells by brute force.
+func nibbleBranch(m Matrix) int {
+	best := 16
+	for x := 1; x < 1<<16; x++ {
+		y := m.Mul(Row{byte(x), byte(x >> 8)})
+		if w := nibbleWeight([]uint64{uint64(x), uint64(y[0]) | uint64(y[1])<<8}); w < best {
+			best = w
+		}
+	}
+
+	return best
+}
+
+func TestBranchNumber(t *testing.T) {
+	m := mixColumns()
+
+	if b := m.DifferentialBranchNumber(8, BranchOptions{}); b != 5 {
+		t.Fatalf("MixColumns had differential branch number %v, not 5.", b)
+	} else if b := m.LinearBranchNumber(8, BranchOptions{Workers: 4}); b != 5 {
+		t.Fatalf("MixColumns had linear branch number %v, not 5.", b)
+	} else if b := GenerateIdentity(32).DifferentialBranchNumber(4, BranchOptions{}); b != 2 {
+		t.Fatalf("Identity had branch number %v, not 2.", b)
+	}
+
+	for i := 0; i < 10; i++ {
+		m := GenerateTrueRandom(rand.Reader, 16)
+		real := nibbleBranch(m)
+
+		if b := m.DifferentialBranchNumber(4, BranchOptions{Workers: 3}); b != real {
+			t.Fatalf("DifferentialBranchNumber returned %v, not %v.", b, real)
+		}
+
+		// With a bound, the answer may be loose but must be on the right side of the bound.
+		for bound := 1; bound <= 6; bound++ {
+			b := m.DifferentialBranchNumber(4, BranchOptions{Bound: bound})
+			if (b < bound) != (real < bound) || b < real {
+				t.Fatalf("Bounded search returned %v with bound %v, but the branch number is %v.", b, bound, real)
+			}
+		}
+	}
+}
+
+func BenchmarkBranchNumber(b *testing.B) {
+	m := mixColumns()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.DifferentialBranchNumber(8, BranchOptions{Workers: 4})
+	}
+}
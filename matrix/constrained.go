@@ -0,0 +1,159 @@
+package matrix
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/OpenWhiteBox/primitives/internal/matching"
+)
+
+// maxConstrainedAttempts is the number of matrices GenerateConstrained will try before deciding its constraints can't
+// be satisfied.
+const maxConstrainedAttempts = 1 << 12
+
+// Constraints describes a family of invertible matrices for GenerateConstrained, in terms of the grid of Size-by-Size
+// blocks that the matrix splits into. Zero and Invertible are bitmaps over the grid, in the same form as returned by
+// Dependencies: the bit in row i and column j refers to block (i, j). Either can be nil.
+type Constraints struct {
+	Size int // The side length of each block, in bits. Zero means the whole matrix is one block.
+
+	Zero       Matrix // Blocks that must be zero, like the off-diagonal blocks of a block-diagonal matrix.
+	Invertible Matrix // Blocks that must be invertible, like the sub-blocks of a Chow-style mixing bijection.
+
+	// Density is the probability that each entry outside of a zero block is one, before conditioning on the other
+	// constraints. Zero means 1/2, which samples uniformly. Densities near 0 or 1 make invertible matrices rare.
+	Density float64
+}
+
+// GenerateConstrained generates a random invertible n-by-n matrix that satisfies the given constraints, and its inverse,
+// using the random source reader (for example, crypto/rand.Reader). With the default density, the matrix is uniformly
+// random among all that satisfy the constraints.
+//
+// Each block is sampled independently--non-invertible samples of an invertible block are rejected--and then the whole
+// matrix is rejected if it isn't invertible. It returns ErrUnsatisfiable if no matrix can satisfy the constraints, or if
+// none is found after many attempts.
+func GenerateConstrained(reader io.Reader, n int, c Constraints) (Matrix, Matrix, error) {
+	size := c.Size
+	if size == 0 {
+		size = n
+	}
+
+	if size <= 0 || n%size != 0 {
+		panic("Can't split matrix into blocks of that size!")
+	} else if c.Density < 0 || c.Density >= 1 {
+		panic("Can't generate matrix with density outside of [0, 1)!")
+	}
+
+	grid := n / size
+	for _, bitmap := range []Matrix{c.Zero, c.Invertible} {
+		if bitmap != nil && (len(bitmap) != grid || bitmap[0].Size() < grid) {
+			panic("Can't use constraint bitmap that is wrong size!")
+		}
+	}
+
+	zero := func(i, j int) bool { return c.Zero != nil && c.Zero[i].GetBit(j) == 1 }
+	invertible := func(i, j int) bool { return c.Invertible != nil && c.Invertible[i].GetBit(j) == 1 }
+
+	// An invertible matrix needs a non-zero block in every block row and column, matched up one-to-one.
+	for i := 0; i < grid; i++ {
+		for j := 0; j < grid; j++ {
+			if zero(i, j) && invertible(i, j) {
+				return nil, nil, ErrUnsatisfiable
+			}
+		}
+	}
+
+	if _, ok := matching.Perfect(grid, func(i, j int) bool { return !zero(i, j) }); !ok {
+		return nil, nil, ErrUnsatisfiable
+	}
+
+	sample := newBitSampler(reader, c.Density)
+	blocks := make([][]Matrix, grid)
+	for i, _ := range blocks {
+		blocks[i] = make([]Matrix, grid)
+	}
+
+	for attempt := 0; attempt < maxConstrainedAttempts; attempt++ {
+		for i := 0; i < grid; i++ {
+			for j := 0; j < grid; j++ {
+				if zero(i, j) {
+					blocks[i][j] = GenerateEmpty(size, size)
+					continue
+				}
+
+				blocks[i][j] = sample.matrix(size)
+				for tries := 0; invertible(i, j) && tries < maxConstrainedAttempts; tries++ {
+					if _, ok := NewSizedMatrix(blocks[i][j], size).Invert(); ok {
+						break
+					}
+
+					blocks[i][j] = sample.matrix(size)
+				}
+			}
+		}
+
+		// AssembleBlocks pads rows to whole bytes, so the padding columns are ignored when n isn't a multiple of 8.
+		m := AssembleBlocks(blocks, size)
+		if mInv, ok := NewSizedMatrix(m, n).Invert(); ok && satisfiesInvertible(m, size, invertible) {
+			return m, mInv.Matrix, nil
+		}
+	}
+
+	return nil, nil, ErrUnsatisfiable
+}
+
+// satisfiesInvertible returns true if every block of m that's required to be invertible is.
+func satisfiesInvertible(m Matrix, size int, invertible func(i, j int) bool) bool {
+	// Blocks past the last column come from the padding when n isn't a multiple of 8, so they're skipped.
+	blocks := m.Blocks(size)
+	for i, blockRow := range blocks {
+		for j, block := range blockRow[:len(blocks)] {
+			if _, ok := NewSizedMatrix(block, size).Invert(); invertible(i, j) && !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// bitSampler generates random bits that are one with a fixed probability.
+type bitSampler struct {
+	reader    io.Reader
+	threshold uint16 // A bit is one if a random 16-bit integer is below threshold. Zero means use raw random bits.
+}
+
+func newBitSampler(reader io.Reader, density float64) bitSampler {
+	if density == 0 || density == 0.5 {
+		return bitSampler{reader: reader}
+	}
+
+	threshold := uint16(density * (1 << 16))
+	if threshold == 0 {
+		threshold = 1
+	}
+
+	return bitSampler{reader, threshold}
+}
+
+// matrix returns a random n-by-n matrix.
+func (bs bitSampler) matrix(n int) Matrix {
+	out := GenerateEmpty(n, n)
+
+	for _, row := range out {
+		if bs.threshold == 0 {
+			io.ReadFull(bs.reader, row)
+			mask(row, n)
+			continue
+		}
+
+		buf := make([]byte, 2*n)
+		io.ReadFull(bs.reader, buf)
+
+		for j := 0; j < n; j++ {
+			row.SetBit(j, binary.LittleEndian.Uint16(buf[2*j:]) < bs.threshold)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,99 @@
+package matrix
+
+import (
+	"testing"
+
+	"crypto/rand"
+)
+
+func TestGenerateConstrainedBlockDiagonal(t *testing.T) {
+	// Block-diagonal with invertible 8-by-8 blocks, like the mixing bijections on each byte of a Chow white-box.
+	zero, invertible := GenerateFull(16, 16), GenerateIdentity(16)
+	for i := 0; i < 16; i++ {
+		zero[i].SetBit(i, false)
+	}
+
+	m, mInv, err := GenerateConstrained(rand.Reader, 128, Constraints{Size: 8, Zero: zero, Invertible: invertible})
+	if err != nil {
+		t.Fatal(err)
+	} else if !m.Compose(mInv).Equals(GenerateIdentity(128)) {
+		t.Fatalf("Inverse is wrong.")
+	} else if !m.Dependencies(8).Equals(invertible) {
+		t.Fatalf("Matrix isn't block-diagonal.")
+	}
+}
+
+func TestGenerateConstrainedInvertibleBlocks(t *testing.T) {
+	// Every 4-by-4 block of a 32-by-32 matrix is invertible.
+	m, mInv, err := GenerateConstrained(rand.Reader, 32, Constraints{Size: 4, Invertible: GenerateFull(8, 8)})
+	if err != nil {
+		t.Fatal(err)
+	} else if !m.Compose(mInv).Equals(GenerateIdentity(32)) {
+		t.Fatalf("Inverse is wrong.")
+	}
+
+	for _, blockRow := range m.Blocks(4) {
+		for _, block := range blockRow {
+			if _, ok := NewSizedMatrix(block, 4).Invert(); !ok {
+				t.Fatalf("Block isn't invertible:\n%v", block)
+			}
+		}
+	}
+}
+
+func TestGenerateConstrainedUneven(t *testing.T) {
+	// A 12-by-12 matrix of invertible nibble blocks, so rows aren't a whole number of bytes.
+	m, mInv, err := GenerateConstrained(rand.Reader, 12, Constraints{Size: 4, Invertible: GenerateFull(3, 3)})
+	if err != nil {
+		t.Fatal(err)
+	} else if !NewSizedMatrix(m, 12).Compose(NewSizedMatrix(mInv, 12)).Equals(GenerateIdentitySized(12)) {
+		t.Fatalf("Inverse is wrong.")
+	}
+
+	for _, blockRow := range m.Blocks(4) {
+		for _, block := range blockRow[:3] { // The fourth column of blocks is padding.
+			if _, ok := NewSizedMatrix(block, 4).Invert(); !ok {
+				t.Fatalf("Block isn't invertible:\n%v", block)
+			}
+		}
+	}
+
+	if _, _, err := GenerateConstrained(rand.Reader, 4, Constraints{}); err != nil {
+		t.Fatalf("Couldn't generate unconstrained 4-by-4 matrix: %v", err)
+	}
+}
+
+func TestGenerateConstrainedDensity(t *testing.T) {
+	m, _, err := GenerateConstrained(rand.Reader, 128, Constraints{Density: 0.1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	weight := 0
+	for _, row := range m {
+		weight += row.Weight()
+	}
+
+	if weight > 128*128/5 {
+		t.Fatalf("Matrix with density 0.1 has weight %v.", weight)
+	}
+}
+
+func TestGenerateConstrainedUnsatisfiable(t *testing.T) {
+	// Block row 0 is all zero.
+	zero := GenerateEmpty(4, 4)
+	zero[0] = Row{0x0f}
+
+	if _, _, err := GenerateConstrained(rand.Reader, 32, Constraints{Size: 8, Zero: zero}); err != ErrUnsatisfiable {
+		t.Fatalf("Generated matrix with a zero block row: %v", err)
+	}
+
+	// Block (1, 1) is both zero and invertible.
+	zero, invertible := GenerateEmpty(4, 4), GenerateEmpty(4, 4)
+	zero[1].SetBit(1, true)
+	invertible[1].SetBit(1, true)
+
+	if _, _, err := GenerateConstrained(rand.Reader, 32, Constraints{Size: 8, Zero: zero, Invertible: invertible}); err != ErrUnsatisfiable {
+		t.Fatalf("Generated matrix with contradictory constraints: %v", err)
+	}
+}
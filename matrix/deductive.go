@@ -9,6 +9,11 @@ type DeductiveMatrix struct {
 	Input, Output IncrementalMatrix
 }
 
+// DeductiveMarker identifies a point in the history of a deductive matrix that it can be rolled back to.
+type DeductiveMarker struct {
+	input, output Marker
+}
+
 // NewDeductiveMatrix returns a new n-by-n deductive matrix.
 func NewDeductiveMatrix(n int) *DeductiveMatrix {
 	return &DeductiveMatrix{
@@ -20,6 +25,24 @@ func NewDeductiveMatrix(n int) *DeductiveMatrix {
 // Assert represents an assertion that A(in) = out. The function will panic if this is inconsistent with previous
 // assertions. It it's not, it returns whether or not the assertion contained new information about A.
 func (dm *DeductiveMatrix) Assert(in, out Row) (learned bool) {
+	learned, err := dm.CheckedAssert(in, out)
+	if err == ErrInconsistentAssertion {
+		panic("Asserted input, output pair is inconsistent with previous assertions!")
+	} else if err != nil {
+		panic("Tried to reduce incorrectly sized row with incremental matrix!")
+	}
+
+	return learned
+}
+
+// CheckedAssert represents an assertion that A(in) = out. It returns ErrInconsistentAssertion if this is inconsistent
+// with previous assertions and ErrDimensionMismatch if either row is the wrong size; A is left unchanged in both cases.
+// Otherwise, it returns whether or not the assertion contained new information about A.
+func (dm *DeductiveMatrix) CheckedAssert(in, out Row) (learned bool, err error) {
+	if in.Size() != dm.Input.n || out.Size() != dm.Output.n {
+		return false, ErrDimensionMismatch
+	}
+
 	inReduced, inInverse := dm.Input.reduce(in)
 	outReduced, outInverse := dm.Output.reduce(out)
 
@@ -27,14 +50,14 @@ func (dm *DeductiveMatrix) Assert(in, out Row) (learned bool) {
 		real := dm.Output.Matrix().Transpose().Mul(inInverse)
 
 		if !real.Equals(out) {
-			panic("Asserted input, output pair is inconsistent with previous assertions!")
+			return false, ErrInconsistentAssertion
 		}
-		return false
+		return false, nil
 	}
 
 	dm.Input.addRows(in, inReduced, inInverse)
 	dm.Output.addRows(out, outReduced, outInverse)
-	return true
+	return true, nil
 }
 
 // FullyDefined returns true if the assertions made give a fully defined matrix.
@@ -58,6 +81,25 @@ func (dm *DeductiveMatrix) Inverse() Matrix {
 	return dm.Output.Inverse().Compose(dm.Input.Matrix()).Transpose()
 }
 
+// Checkpoint returns a marker for the current state of the deductive matrix, which can be passed to Rollback to undo
+// every assertion made after it.
+func (dm *DeductiveMatrix) Checkpoint() DeductiveMarker {
+	return DeductiveMarker{dm.Input.Checkpoint(), dm.Output.Checkpoint()}
+}
+
+// Rollback undoes every assertion made since the given marker was returned by Checkpoint.
+func (dm *DeductiveMatrix) Rollback(marker DeductiveMarker) {
+	dm.Input.Rollback(marker.input)
+	dm.Output.Rollback(marker.output)
+}
+
+// Commit keeps every assertion made since the given marker was returned by Checkpoint. Every checkpoint should be
+// rolled back or committed, so that the deductive matrix can stop logging changes.
+func (dm *DeductiveMatrix) Commit(marker DeductiveMarker) {
+	dm.Input.Commit(marker.input)
+	dm.Output.Commit(marker.output)
+}
+
 // Dup returns a duplicate of dm.
 func (dm *DeductiveMatrix) Dup() *DeductiveMatrix {
 	return &DeductiveMatrix{
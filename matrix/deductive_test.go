@@ -105,3 +105,37 @@ func TestDeductiveMatrixFailure3(t *testing.T) {
 	dm.Assert(in, out)
 	t.Fatal("Goroutine did not panic when it should've!")
 }
+
+func TestDeductiveMatrixCheckedAssert(t *testing.T) {
+	dm := testingDeductiveMatrix()
+
+	// Generate a random un-novel input element and a random novel output element.
+	in := dm.Input.Matrix().Transpose().Mul(GenerateRandomRow(rand.Reader, 128))
+	out := GenerateRandomRow(rand.Reader, 128)
+	for dm.Output.IsIn(out) {
+		out = GenerateRandomRow(rand.Reader, 128)
+	}
+
+	if _, err := dm.CheckedAssert(in, out); err != ErrInconsistentAssertion {
+		t.Fatalf("CheckedAssert didn't report inconsistent assertion: %v", err)
+	} else if _, err := dm.CheckedAssert(NewRow(64), out); err != ErrDimensionMismatch {
+		t.Fatalf("CheckedAssert didn't report wrong size row: %v", err)
+	} else if dm.Input.Len() != 126 || dm.Output.Len() != 126 {
+		t.Fatal("CheckedAssert mutated state on failed assertion.")
+	}
+}
+
+func TestDeductiveMatrixRollback(t *testing.T) {
+	dm := testingDeductiveMatrix()
+	mark := dm.Checkpoint()
+
+	// Finish the matrix off with wrong guesses, then undo them.
+	for !dm.FullyDefined() {
+		dm.CheckedAssert(GenerateRandomRow(rand.Reader, 128), GenerateRandomRow(rand.Reader, 128))
+	}
+	dm.Rollback(mark)
+
+	if dm.FullyDefined() || len(dm.Input.raw) != 126 || len(dm.Output.raw) != 126 {
+		t.Fatalf("Rollback didn't undo assertions.")
+	}
+}
@@ -0,0 +1,22 @@
+package matrix
+
+import (
+	"errors"
+)
+
+var (
+	// ErrDimensionMismatch is returned when an operation is given rows or matrices of incompatible sizes.
+	ErrDimensionMismatch = errors.New("matrix: dimension mismatch")
+
+	// ErrInconsistentAssertion is returned when an assertion about a deduced matrix contradicts the previous ones.
+	ErrInconsistentAssertion = errors.New("matrix: asserted input, output pair is inconsistent with previous assertions")
+
+	// ErrNoSolution is returned when a linear system is inconsistent.
+	ErrNoSolution = errors.New("matrix: linear system has no solution")
+
+	// ErrUnsatisfiable is returned when no matrix can be generated that satisfies a set of constraints.
+	ErrUnsatisfiable = errors.New("matrix: constraints can't be satisfied")
+
+	// ErrMalformed is returned when a serialized matrix can't be parsed.
+	ErrMalformed = errors.New("matrix: malformed serialized matrix")
+)
@@ -0,0 +1,204 @@
+package matrix
+
+import (
+	"math/bits"
+)
+
+// RowOp is an elementary row operation on a vector or matrix: row Src is added into row Dst or, if Swap is true, rows
+// Dst and Src are swapped.
+type RowOp struct {
+	Dst, Src int
+	Swap     bool
+}
+
+// RowOps is a sequence of elementary row operations, applied in order. Each one costs one XOR (or nothing, for a swap),
+// so a short sequence is a cheap way to evaluate or embed a linear layer.
+type RowOps []RowOp
+
+// Apply returns the result of applying the row operations to x.
+func (ops RowOps) Apply(x Row) Row {
+	out := x.Dup()
+
+	for _, op := range ops {
+		a, b := out.GetBit(op.Dst), out.GetBit(op.Src)
+
+		if op.Swap {
+			out.SetBit(op.Dst, b == 1)
+			out.SetBit(op.Src, a == 1)
+		} else {
+			out.SetBit(op.Dst, a^b == 1)
+		}
+	}
+
+	return out
+}
+
+// Matrix returns the n-by-n matrix of the row operations, so that ops.Matrix(n).Mul(x) = ops.Apply(x).
+func (ops RowOps) Matrix(n int) Matrix {
+	out := GenerateIdentity(n)
+
+	for _, op := range ops {
+		if op.Swap {
+			out[op.Dst], out[op.Src] = out[op.Src], out[op.Dst]
+		} else {
+			out[op.Dst] = out[op.Dst].Add(out[op.Src])
+		}
+	}
+
+	return out
+}
+
+// Cost returns the number of XORs needed to apply the row operations.
+func (ops RowOps) Cost() (cost int) {
+	for _, op := range ops {
+		if !op.Swap {
+			cost++
+		}
+	}
+
+	return
+}
+
+// PLU returns a permutation matrix p, a lower unitriangular matrix l, and an upper unitriangular matrix u such that
+// e = p * l * u. It returns false if e isn't square and invertible.
+func (e Matrix) PLU() (p, l, u Matrix, ok bool) {
+	n, m := e.Size()
+	if n != m {
+		return nil, nil, nil, false
+	}
+
+	// Row i of l * u is row perm[i] of e.
+	perm := make([]int, n)
+	for i, _ := range perm {
+		perm[i] = i
+	}
+
+	l, u = GenerateEmpty(n, n), e.Dup()
+
+	for col := 0; col < n; col++ {
+		pivot := u.FindPivot(col, col)
+		if pivot == -1 {
+			return nil, nil, nil, false
+		}
+
+		u[col], u[pivot] = u[pivot], u[col]
+		l[col], l[pivot] = l[pivot], l[col]
+		perm[col], perm[pivot] = perm[pivot], perm[col]
+
+		for i := col + 1; i < n; i++ {
+			if u[i].GetBit(col) == 1 {
+				u[i] = u[i].Add(u[col])
+				l[i].SetBit(col, true)
+			}
+		}
+	}
+
+	p = GenerateEmpty(n, n)
+	for i, j := range perm {
+		l[i].SetBit(i, true)
+		p[j].SetBit(i, true)
+	}
+
+	return p, l, u, true
+}
+
+// RowOps factors the matrix into a short sequence of elementary row operations, so that e.Mul(x) = ops.Apply(x). It
+// returns false if e isn't square and invertible.
+//
+// Finding the shortest sequence is hard, so this tries a greedy heuristic--repeatedly adding the pair of rows that
+// lowers the weight of the matrix the most--alongside plain Gauss-Jordan elimination, and returns the cheaper.
+func (e Matrix) RowOps() (RowOps, bool) {
+	n, m := e.Size()
+	if n != m {
+		return nil, false
+	} else if _, ok := e.Invert(); !ok {
+		return nil, false
+	}
+
+	greedy := reduceGreedy(e.pack(), nil)
+	plain := reduceElimination(e.pack(), nil)
+
+	if plain.Cost() < greedy.Cost() || plain.Cost() == greedy.Cost() && len(plain) < len(greedy) {
+		greedy = plain
+	}
+
+	// The operations reduce e to the identity and each is its own inverse, so e is their product in reverse order.
+	out := make(RowOps, len(greedy))
+	for i, op := range greedy {
+		out[len(out)-1-i] = op
+	}
+
+	return out, true
+}
+
+// reduceGreedy reduces w towards the identity by adding whichever row into another lowers the total weight of w the
+// most, and then finishes with Gauss-Jordan elimination once that's no longer possible. It appends the operations
+// used to ops.
+func reduceGreedy(w *words, ops RowOps) RowOps {
+	weights := make([]int, w.rows)
+	for i, _ := range weights {
+		weights[i] = wordsWeight(w.row(i))
+	}
+
+	for {
+		best, dst, src := 0, -1, -1
+
+		for i := 0; i < w.rows; i++ {
+			for j := 0; j < w.rows; j++ {
+				if i == j {
+					continue
+				}
+
+				sum, a, b := 0, w.row(i), w.row(j)
+				for k, _ := range a {
+					sum += bits.OnesCount64(a[k] ^ b[k])
+				}
+
+				if gain := weights[i] - sum; gain > best {
+					best, dst, src = gain, i, j
+				}
+			}
+		}
+
+		if dst == -1 {
+			return reduceElimination(w, ops)
+		}
+
+		xorRow(w.row(dst), w.row(src), 0)
+		weights[dst] -= best
+		ops = append(ops, RowOp{Dst: dst, Src: src})
+	}
+}
+
+// reduceElimination reduces w to the identity with Gauss-Jordan elimination, appending the operations used to ops.
+func reduceElimination(w *words, ops RowOps) RowOps {
+	for col := 0; col < w.rows; col++ {
+		pivot := col
+		for w.bit(pivot, col) == 0 {
+			pivot++
+		}
+
+		if pivot != col {
+			w.swap(pivot, col)
+			ops = append(ops, RowOp{Dst: col, Src: pivot, Swap: true})
+		}
+
+		for i := 0; i < w.rows; i++ {
+			if i != col && w.bit(i, col) == 1 {
+				xorRow(w.row(i), w.row(col), 0)
+				ops = append(ops, RowOp{Dst: i, Src: col})
+			}
+		}
+	}
+
+	return ops
+}
+
+// wordsWeight returns the hamming weight of a packed row.
+func wordsWeight(row []uint64) (w int) {
+	for _, x := range row {
+		w += bits.OnesCount64(x)
+	}
+
+	return
+}
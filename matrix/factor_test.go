@@ -0,0 +1,71 @@
+package matrix
+
+import (
+	"testing"
+
+	"crypto/rand"
+)
+
+func TestPLU(t *testing.T) {
+	for _, n := range []int{8, 32, 128} {
+		m := GenerateRandom(rand.Reader, n)
+
+		p, l, u, ok := m.PLU()
+		if !ok {
+			t.Fatalf("PLU failed on invertible %v-by-%v matrix.", n, n)
+		} else if !p.Compose(l).Compose(u).Equals(m) {
+			t.Fatalf("p * l * u isn't the original %v-by-%v matrix.", n, n)
+		}
+
+		for i := 0; i < n; i++ {
+			if p[i].Weight() != 1 || l[i].GetBit(i) != 1 || u[i].GetBit(i) != 1 {
+				t.Fatalf("PLU factors of %v-by-%v matrix have the wrong form.", n, n)
+			}
+
+			for j := i + 1; j < n; j++ {
+				if l[i].GetBit(j) != 0 || u[j].GetBit(i) != 0 {
+					t.Fatalf("PLU factors of %v-by-%v matrix aren't triangular.", n, n)
+				}
+			}
+		}
+	}
+
+	if _, _, _, ok := GenerateEmpty(8, 8).PLU(); ok {
+		t.Fatalf("PLU succeeded on singular matrix.")
+	}
+}
+
+func TestRowOps(t *testing.T) {
+	for _, n := range []int{8, 32, 128} {
+		m := GenerateRandom(rand.Reader, n)
+
+		ops, ok := m.RowOps()
+		if !ok {
+			t.Fatalf("RowOps failed on invertible %v-by-%v matrix.", n, n)
+		} else if !ops.Matrix(n).Equals(m) {
+			t.Fatalf("Row operations don't multiply out to the original %v-by-%v matrix.", n, n)
+		}
+
+		x := GenerateRandomRow(rand.Reader, n)
+		if !ops.Apply(x).Equals(m.Mul(x)) {
+			t.Fatalf("Applying row operations doesn't match multiplying by the %v-by-%v matrix.", n, n)
+		}
+
+		t.Logf("%v-by-%v: %v XORs", n, n, ops.Cost())
+	}
+}
+
+func TestRowOpsSparse(t *testing.T) {
+	// A matrix that's three row operations from the identity should be found to be no more expensive than that.
+	ops := RowOps{{Dst: 0, Src: 5}, {Dst: 3, Src: 0}, {Dst: 1, Src: 7}, {Dst: 2, Src: 6, Swap: true}}
+	m := ops.Matrix(16)
+
+	found, ok := m.RowOps()
+	if !ok {
+		t.Fatalf("RowOps failed on invertible matrix.")
+	} else if !found.Matrix(16).Equals(m) {
+		t.Fatalf("Row operations don't multiply out to the original matrix.")
+	} else if found.Cost() > ops.Cost() {
+		t.Fatalf("RowOps found %v XORs, more than %v.", found.Cost(), ops.Cost())
+	}
+}
@@ -2,50 +2,31 @@ package matrix
 
 // gaussJordan reduces the matrix according to the Gauss-Jordan Method.  Returns the augment matrix, the transformed
 // matrix, and the set set of free variables.
+//
+// The augment matrix is the product of the row operations performed, so aug.Compose(e) = f. The reduction itself is
+// done on packed words with the Method of Four Russians.
 func (e Matrix) gaussJordan() (aug, f Matrix, frees []int) {
 	out, in := e.Size()
 
-	aug = GenerateIdentity(in)
-	for x := in; x < out; x++ {
-		aug = append(aug, NewRow(in))
+	// Pack e side-by-side with the identity matrix, so the row operations are recorded in the last out columns.
+	w := newWords(out, in+out)
+	for i, row := range e {
+		packRow(w.row(i), row, 0)
+		w.flip(i, in+i)
 	}
 
-	f = e.Dup() // Duplicate e away so we don't mutate it.
+	pivots := w.reduce(in)
 
-	row, col := 0, 0
-
-	for row < out && col < in {
-		// Find a non-zero element to move into the pivot position.
-		i := f.FindPivot(row, col)
-		if i == -1 { // Failed to find a pivot.
+	// Every column without a pivot is a free variable.
+	for col, k := 0, 0; col < in; col++ {
+		if k < len(pivots) && pivots[k] == col {
+			k++
+		} else {
 			frees = append(frees, col)
-			col++
-
-			continue
-		}
-
-		// Move it into position.
-		f[row], f[i] = f[i], f[row]
-		aug[row], aug[i] = aug[i], aug[row]
-
-		// Cancel out the (row)th position for every row above and below it.
-		for j, _ := range f {
-			if j != row && f[j].GetBit(col) != 0 {
-				aug[j] = aug[j].Add(aug[row])
-				f[j] = f[j].Add(f[row])
-			}
 		}
-
-		row++
-		col++
 	}
 
-	// Add the rest of the free variables for completion.
-	for x := out; x < in; x++ {
-		frees = append(frees, x)
-	}
-
-	return
+	return w.unpack(in, out), w.unpack(0, in), frees
 }
 
 // NullSpace returns a basis for the matrix's nullspace.
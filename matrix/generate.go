@@ -1,8 +1,8 @@
 package matrix
 
 import (
-	"crypto/rand"
 	"io"
+	"math/bits"
 )
 
 // GenerateIdentity generates the n-by-n identity matrix.
@@ -51,7 +51,27 @@ func GenerateEmpty(n, m int) Matrix {
 // GenerateRandomRow generates a random n-component row.
 func GenerateRandomRow(reader io.Reader, n int) Row {
 	out := Row(make([]byte, rowsToColumns(n)))
-	reader.Read(out)
+	io.ReadFull(reader, out)
+
+	return out
+}
+
+// generateRandomTail generates a random n-component row which is zero before position i.
+func generateRandomTail(reader io.Reader, n, i int) Row {
+	if i >= n {
+		return NewRow(n)
+	}
+
+	out := GenerateRandomRow(reader, n)
+
+	for j := 0; j < i/8; j++ {
+		out[j] = 0
+	}
+	out[i/8] &^= byte(1<<uint(i%8)) - 1
+
+	if n%8 != 0 {
+		out[len(out)-1] &= byte(1<<uint(n%8)) - 1
+	}
 
 	return out
 }
@@ -70,14 +90,73 @@ func GenerateRandomNonZeroRow(reader io.Reader, n int) Row {
 // GenerateRandom generates a random invertible n-by-n matrix using the random source random (for example,
 // crypto/rand.Reader).
 func GenerateRandom(reader io.Reader, n int) Matrix {
-	m := GenerateTrueRandom(reader, n)
+	m, _ := GenerateRandomWithInverse(reader, n)
+	return m
+}
 
-	_, ok := m.Invert()
-	if !ok { // Try again
-		return GenerateRandom(reader, n) // Performance bottleneck.
+// GenerateRandomWithInverse generates a uniformly random invertible n-by-n matrix and its inverse using the random
+// source reader (for example, crypto/rand.Reader). No randomness is used besides what's read from reader, so a seeded
+// reader always gives the same matrix.
+//
+// A uniformly random invertible matrix M has a uniformly random non-zero first column c. If E is a fixed invertible
+// matrix with first column c, then M = E * [[1, w], [0, M']] where w is a uniformly random row and M' is a uniformly
+// random invertible matrix one size smaller. M is built by unrolling this from the bottom-right corner up, which is a
+// random PLU decomposition. Nothing is ever rejected except a zero vector when a non-zero c is needed, and the inverse
+// is tracked with row operations along the way.
+func GenerateRandomWithInverse(reader io.Reader, n int) (Matrix, Matrix) {
+	// m holds M and inv holds the transpose of M's inverse, so that both are updated with row operations.
+	m, inv := newWords(n, n), newWords(n, n)
+	b, nb, w := make([]uint64, m.stride), make([]uint64, m.stride), make([]uint64, m.stride)
+
+	for i := n - 1; i >= 0; i-- {
+		c := generateRandomTail(reader, n, i)
+		for c.IsZero() {
+			c = generateRandomTail(reader, n, i)
+		}
+
+		for j := range w {
+			w[j] = 0
+		}
+		packRow(w, generateRandomTail(reader, n, i+1), 0)
+
+		// B = [[1, w], [0, M']], so B^-1 = [[1, w M'^-1], [0, M'^-1]].
+		copy(m.row(i), w)
+		m.flip(i, i)
+
+		for r := i + 1; r < n; r++ {
+			parity := 0
+			for j, inv_r := range inv.row(r) {
+				parity += bits.OnesCount64(inv_r & w[j])
+			}
+
+			if parity&1 == 1 {
+				inv.flip(r, i)
+			}
+		}
+		inv.flip(i, i)
+
+		// E's first column is c and its other columns are the unit vectors except e_k, where k is c's first non-zero
+		// entry. Left-multiplying B by E moves B's first row to position k and adds it to every other row where c is
+		// one. Left-multiplying the transposed inverse by the transpose of E^-1 moves its first row to position k and
+		// adds every other row where c is one to it.
+		k := c.Height()
+
+		copy(b, m.row(i))
+		copy(nb, inv.row(i))
+		copy(m.data[i*m.stride:k*m.stride], m.data[(i+1)*m.stride:(k+1)*m.stride])
+		copy(inv.data[i*inv.stride:k*inv.stride], inv.data[(i+1)*inv.stride:(k+1)*inv.stride])
+		copy(m.row(k), b)
+		copy(inv.row(k), nb)
+
+		for t := k + 1; t < n; t++ { // c is zero before k.
+			if c.GetBit(t) == 1 {
+				xorRow(m.row(t), b, 0)
+				xorRow(inv.row(k), inv.row(t), 0)
+			}
+		}
 	}
 
-	return m // This one works!
+	return m.unpack(0, n), inv.transpose()
 }
 
 // GenerateRandomPartial generates an invertible n-by-n matrix which is random in some locations and the identity / zero
@@ -109,7 +188,7 @@ func GenerateTrueRandom(reader io.Reader, n int) Matrix {
 	m := make([]Row, n)
 
 	for i, _ := range m { // Generate random n x n matrix.
-		m[i] = GenerateRandomRow(rand.Reader, n)
+		m[i] = GenerateRandomRow(reader, n)
 	}
 
 	return m
@@ -1,6 +1,7 @@
 package matrix
 
 import (
+	"math/big"
 	"sort"
 )
 
@@ -16,8 +17,31 @@ type IncrementalMatrix struct {
 	simplest Matrix // The matrix in Gauss-Jordan eliminated form.
 	inverse  Matrix // The inverse matrix of raw.
 	frees    []int  // Set of free variables.
+
+	log   []undo // Every change made since the first outstanding checkpoint, so that it can be undone.
+	depth int    // The number of outstanding checkpoints. Changes are only logged while it's positive.
+}
+
+// Marker identifies a point in the history of an incremental matrix that it can be rolled back to.
+type Marker struct {
+	length, depth int // The length of the undo log, and the number of outstanding checkpoints including this one.
+}
+
+// undo is an entry in an incremental matrix's undo log.
+type undo struct {
+	kind              undoKind
+	i, j              int
+	simplest, inverse Row
 }
 
+type undoKind int
+
+const (
+	undoAppend    undoKind = iota // A row was appended and free variable i was removed.
+	undoOverwrite                 // Row i of simplest and inverse was overwritten; the old rows are saved.
+	undoSwap                      // Rows i and j of simplest and inverse were swapped.
+)
+
 // NewIncrementalMatrix initializes a new n-by-n incremental matrix.
 func NewIncrementalMatrix(n int) IncrementalMatrix {
 	frees := make([]int, n)
@@ -63,6 +87,7 @@ func (im *IncrementalMatrix) addRows(raw, reduced, inverse Row) {
 	// Cancel every other row in the simplest form with cand.
 	for i, _ := range im.simplest {
 		if reduced.Cancels(im.simplest[i]) {
+			im.record(undo{kind: undoOverwrite, i: i, simplest: im.simplest[i], inverse: im.inverse[i]})
 			im.simplest[i] = im.simplest[i].Add(reduced)
 			im.inverse[i] = im.inverse[i].Add(inverse)
 		}
@@ -74,6 +99,74 @@ func (im *IncrementalMatrix) addRows(raw, reduced, inverse Row) {
 
 	idx := sort.SearchInts(im.frees, reduced.Height())
 	im.frees = append(im.frees[0:idx], im.frees[idx+1:]...)
+
+	im.record(undo{kind: undoAppend, i: reduced.Height()})
+}
+
+// record adds an entry to the undo log, if there's a checkpoint that it could be rolled back to.
+func (im *IncrementalMatrix) record(entry undo) {
+	if im.depth > 0 {
+		im.log = append(im.log, entry)
+	}
+}
+
+// Checkpoint returns a marker for the current state of the matrix, which can be passed to Rollback to undo every change
+// made after it, or to Commit to keep them. This is much cheaper than Dup in backtracking searches, which can mutate one
+// matrix in place. Changes are only logged while a checkpoint is outstanding, so every checkpoint should eventually be
+// rolled back or committed.
+func (im *IncrementalMatrix) Checkpoint() Marker {
+	im.depth++
+	return Marker{len(im.log), im.depth}
+}
+
+// Rollback undoes every change made to the matrix since the given marker was returned by Checkpoint. The marker and
+// every one returned after it are invalid afterwards.
+func (im *IncrementalMatrix) Rollback(marker Marker) {
+	im.checkMarker(marker)
+
+	for k := len(im.log) - 1; k >= marker.length; k-- {
+		entry := im.log[k]
+
+		switch entry.kind {
+		case undoAppend:
+			last := len(im.raw) - 1
+			im.raw, im.simplest, im.inverse = im.raw[:last], im.simplest[:last], im.inverse[:last]
+
+			idx := sort.SearchInts(im.frees, entry.i)
+			im.frees = append(im.frees, 0)
+			copy(im.frees[idx+1:], im.frees[idx:])
+			im.frees[idx] = entry.i
+		case undoOverwrite:
+			im.simplest[entry.i], im.inverse[entry.i] = entry.simplest, entry.inverse
+		case undoSwap:
+			im.simplest[entry.i], im.simplest[entry.j] = im.simplest[entry.j], im.simplest[entry.i]
+			im.inverse[entry.i], im.inverse[entry.j] = im.inverse[entry.j], im.inverse[entry.i]
+		}
+	}
+
+	im.log = im.log[:marker.length]
+	im.release(marker)
+}
+
+// Commit keeps every change made to the matrix since the given marker was returned by Checkpoint. The marker and every
+// one returned after it are invalid afterwards, but earlier ones can still roll the changes back.
+func (im *IncrementalMatrix) Commit(marker Marker) {
+	im.checkMarker(marker)
+	im.release(marker)
+}
+
+// checkMarker panics if the marker isn't outstanding.
+func (im *IncrementalMatrix) checkMarker(marker Marker) {
+	if marker.depth < 1 || marker.depth > im.depth || marker.length > len(im.log) {
+		panic("Can't use a marker that's invalid!")
+	}
+}
+
+// release forgets the given checkpoint and every one after it. Once none are left, the undo log is dropped.
+func (im *IncrementalMatrix) release(marker Marker) {
+	if im.depth = marker.depth - 1; im.depth == 0 {
+		im.log = nil
+	}
 }
 
 // Add tries to add the row to the matrix. It fails if the new row is linearly dependent with another row. Add returns
@@ -131,23 +224,42 @@ func (im *IncrementalMatrix) NovelSize() int {
 // NovelRow returns the nth row that is NOT a linear combination of the known rows of the matrix. n will be considered
 // modulo im.NovelSize().
 func (im *IncrementalMatrix) NovelRow(n int) Row {
+	return im.novelRow(big.NewInt(int64(n)))
+}
+
+// novelSize is NovelSize as a big.Int, because 2^k * (2^(n-k) - 1) doesn't fit in an int once n reaches 63.
+func (im *IncrementalMatrix) novelSize() *big.Int {
+	size := new(big.Int).Lsh(big.NewInt(1), uint(len(im.frees)))
+	size.Sub(size, big.NewInt(1))
+
+	return size.Lsh(size, uint(len(im.raw)))
+}
+
+// novelRow is NovelRow with a big.Int index, which it considers modulo im.novelSize().
+func (im *IncrementalMatrix) novelRow(n *big.Int) Row {
 	if im.FullyDefined() {
 		return nil
 	}
 
-	// Extract choices for free variables and rows.
-	n = n % im.NovelSize()
-	free := (n % im.freeSize()) + 1
-	raw := n / im.freeSize()
+	// Extract choices for free variables and rows. The free variables are the low bits of n, offset by one to skip the
+	// empty combination, and the rows are the high bits.
+	free, raw := new(big.Int), new(big.Int).Mod(n, im.novelSize())
+	freeSize := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(len(im.frees))), big.NewInt(1))
+	raw.DivMod(raw, freeSize, free)
+	free.Add(free, big.NewInt(1))
 
 	out := NewRow(im.n)
-	for i := uint(0); i < uint(len(im.frees)); i++ { // Set all chosen free variables to true.
-		if (free>>i)&1 == 1 {
-			out.SetBit(im.frees[i], true)
+	for i, pos := range im.frees { // Set all chosen free variables to true.
+		if free.Bit(i) == 1 {
+			out.SetBit(pos, true)
 		}
 	}
 
-	out = out.Add(im.Row(raw)) // Add the chosen rows from the raw matrix.
+	for i, row := range im.raw { // Add the chosen rows from the raw matrix.
+		if raw.Bit(i) == 1 {
+			out = out.Add(row)
+		}
+	}
 
 	return out
 }
@@ -174,7 +286,7 @@ func (im *IncrementalMatrix) Inverse() Matrix {
 	return im.pad(im.inverse)
 }
 
-// Dup returns a duplicate of im.
+// Dup returns a duplicate of im. The duplicate has no history: markers from im can't be used with it.
 func (im *IncrementalMatrix) Dup() IncrementalMatrix {
 	frees := make([]int, len(im.frees))
 	copy(frees, im.frees)
@@ -199,6 +311,7 @@ func (im *IncrementalMatrix) Less(i, j int) bool {
 }
 
 func (im *IncrementalMatrix) Swap(i, j int) {
+	im.record(undo{kind: undoSwap, i: i, j: j})
 	im.simplest[i], im.simplest[j] = im.simplest[j], im.simplest[i]
 	im.inverse[i], im.inverse[j] = im.inverse[j], im.inverse[i]
 }
@@ -2,6 +2,7 @@ package matrix
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"crypto/rand"
@@ -102,3 +103,104 @@ func TestIncrementalNovel(t *testing.T) {
 		}
 	}
 }
+
+func TestIncrementalMatrixRollback(t *testing.T) {
+	im := NewIncrementalMatrix(64)
+	m := GenerateRandom(rand.Reader, 64)
+
+	for _, row := range m[0:20] {
+		im.Add(row)
+	}
+
+	before := im.Dup()
+	mark := im.Checkpoint()
+
+	for _, row := range m[20:40] {
+		im.Add(row)
+	}
+	im.Inverse() // Sorts the rows, so swaps have to be undone too.
+
+	middle := im.Dup()
+	inner := im.Checkpoint()
+
+	for _, row := range m[40:] {
+		im.Add(row)
+	}
+	im.Inverse()
+
+	im.Rollback(inner)
+	if !sameIncremental(&im, &middle) {
+		t.Fatalf("Rollback to inner marker didn't restore the matrix.")
+	}
+
+	im.Rollback(mark)
+	if !sameIncremental(&im, &before) {
+		t.Fatalf("Rollback to outer marker didn't restore the matrix.")
+	}
+
+	// The matrix should still work normally after being rolled back.
+	for _, row := range m[20:] {
+		if !im.Add(row) {
+			t.Fatalf("Failed to add row after rollback.")
+		}
+	}
+
+	if !im.Matrix().Equals(m) {
+		t.Fatalf("Matrix after rollback is wrong.")
+	}
+}
+
+func TestIncrementalMatrixCommit(t *testing.T) {
+	im := NewIncrementalMatrix(64)
+	m := GenerateRandom(rand.Reader, 64)
+
+	// Nothing is logged without a checkpoint, including the swaps from sorting.
+	for _, row := range m[0:16] {
+		im.Add(row)
+	}
+	im.Inverse()
+
+	if len(im.log) != 0 {
+		t.Fatalf("Incremental matrix logged %v changes without a checkpoint.", len(im.log))
+	}
+
+	before := im.Dup()
+	outer := im.Checkpoint()
+
+	for _, row := range m[16:32] {
+		im.Add(row)
+	}
+
+	inner := im.Checkpoint()
+	for _, row := range m[32:] {
+		im.Add(row)
+	}
+	im.Inverse()
+
+	// Committing the inner checkpoint keeps its changes, but the outer one can still undo them.
+	im.Commit(inner)
+	if !im.FullyDefined() || len(im.log) == 0 {
+		t.Fatalf("Commit to inner marker lost changes or the undo log.")
+	}
+
+	im.Rollback(outer)
+	if !sameIncremental(&im, &before) {
+		t.Fatalf("Rollback to outer marker didn't undo committed changes.")
+	} else if im.log != nil || im.depth != 0 {
+		t.Fatalf("Undo log wasn't dropped after the last checkpoint was released.")
+	}
+}
+
+func sameIncremental(a, b *IncrementalMatrix) bool {
+	if len(a.frees) != len(b.frees) {
+		return false
+	}
+	for i, free := range a.frees {
+		if free != b.frees[i] {
+			return false
+		}
+	}
+
+	return reflect.DeepEqual(a.raw, b.raw) && reflect.DeepEqual(a.simplest, b.simplest) &&
+		reflect.DeepEqual(a.inverse, b.inverse)
+}
@@ -0,0 +1,160 @@
+package matrix
+
+// Kronecker returns the Kronecker product of a and b: the block matrix whose (i, j)th block is a[i][j] * b. With Vec,
+// it turns matrix equations into linear systems: (A X B).Vec() = Kronecker(B^T, A).Mul(X.Vec()).
+func Kronecker(a, b Matrix) Matrix {
+	n, m := a.Size()
+	p, q := b.Size()
+
+	w := newWords(n*p, m*q)
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			if a[i].GetBit(j) == 0 {
+				continue
+			}
+
+			for k, row := range b {
+				packRow(w.row(i*p+k), row, j*q)
+			}
+		}
+	}
+
+	return w.unpack(0, m*q)
+}
+
+// Vec returns the columns of the matrix stacked on top of each other, as one row: bit j*n + i of the output is the
+// entry in row i and column j of an n-row matrix.
+func (e Matrix) Vec() Row {
+	n, m := e.Size()
+	out := NewRow(n * m)
+
+	for i, row := range e {
+		for j := 0; j < m; j++ {
+			if row.GetBit(j) == 1 {
+				out.SetBit(j*n+i, true)
+			}
+		}
+	}
+
+	return out
+}
+
+// Unvec is the inverse of Vec: it returns the n-by-m matrix whose stacked columns are v.
+func Unvec(v Row, n, m int) Matrix {
+	if v.Size() != rowsToColumns(n*m)*8 {
+		panic("Can't unstack row that is wrong size!")
+	}
+
+	out := GenerateEmpty(n, m)
+
+	for i, row := range out {
+		for j := 0; j < m; j++ {
+			row.SetBit(j, v.GetBit(j*n+i) == 1)
+		}
+	}
+
+	return out
+}
+
+// SolveSylvester returns a basis for the space of all X with a * X = X * b, where a is n-by-n and b is m-by-m. With
+// a == b, this is the commutant of a.
+//
+// Rather than solving the nm-variable system from Kronecker, it puts b in rational canonical form. Each companion
+// block of b with polynomial g then contributes one solution for every vector z in the kernel of g(a), whose columns are
+// z, a*z, a^2*z, and so on.
+func SolveSylvester(a, b Matrix) (basis []Matrix) {
+	n, n2 := a.Size()
+	m, m2 := b.Size()
+	if n != n2 || m != m2 {
+		panic("Can't solve Sylvester equation with non-square matrices!")
+	}
+
+	t, blocks := b.canonicalBasis()
+	tInv, _ := t.Invert()
+
+	// Solutions Z to a * Z = Z * C, where C = t^-1 * b * t, are built column-by-column and then mapped back to solutions
+	// X = Z * t^-1.
+	for offset, k := 0, 0; k < len(blocks); k++ {
+		size := blocks[k].Degree()
+
+		for _, z := range blocks[k].Eval(a).Kernel().Basis() {
+			cols := GenerateEmpty(m, n)
+			for i := 0; i < size; i, z = i+1, a.Mul(z) {
+				cols[offset+i] = z
+			}
+
+			basis = append(basis, cols.Transpose().Compose(tInv))
+		}
+
+		offset += size
+	}
+
+	return
+}
+
+// SolveAXB returns a solution X to a * X * b = c and a basis for the space of all X with a * X * b = 0, so that every
+// solution is the first plus some combination of the second. It returns ErrNoSolution if there's no solution and
+// ErrDimensionMismatch if the sizes of the matrices don't fit together.
+func SolveAXB(a, b, c Matrix) (x Matrix, basis []Matrix, err error) {
+	n, p := a.Size()
+	q, m := b.Size()
+	if n2, m2 := c.Size(); n != n2 || m != m2 {
+		return nil, nil, ErrDimensionMismatch
+	}
+
+	// With ua * a * va and ub * b * vb both zero except for an identity in the top-left corner, the equation becomes
+	// one where Y = va^-1 * X * ub^-1 is fixed in the top-left corner and free elsewhere.
+	ua, va, ra := a.rankForm()
+	ub, vb, rb := b.rankForm()
+
+	cc := ua.Compose(c).Compose(vb)
+	y := GenerateEmpty(p, q)
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			if cc[i].GetBit(j) == 0 {
+				continue
+			} else if i >= ra || j >= rb {
+				return nil, nil, ErrNoSolution
+			}
+
+			y[i].SetBit(j, true)
+		}
+	}
+
+	x = va.Compose(y).Compose(ub)
+
+	// Each free entry (i, j) of Y contributes va * E_ij * ub, the product of the ith column of va with the jth row of ub.
+	for i := 0; i < p; i++ {
+		for j := 0; j < q; j++ {
+			if i < ra && j < rb {
+				continue
+			}
+
+			sol := GenerateEmpty(p, q)
+			for r, row := range va {
+				if row.GetBit(i) == 1 {
+					sol[r] = ub[j].Dup()
+				}
+			}
+
+			basis = append(basis, sol)
+		}
+	}
+
+	return
+}
+
+// rankForm returns invertible matrices u and v, and the rank r of e, such that u * e * v is zero except for an r-by-r
+// identity matrix in its top-left corner.
+func (e Matrix) rankForm() (u, v Matrix, r int) {
+	_, in := e.Size()
+
+	// Row reduction leaves the pivot rows on top. Column reduction, as row reduction of the transpose, then moves the
+	// pivots into the corner and clears the rest of each pivot row.
+	u, f, frees := e.gaussJordan()
+	vt, _, _ := f.Transpose().gaussJordan()
+
+	return u, vt.Transpose(), in - len(frees)
+}
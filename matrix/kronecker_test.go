@@ -0,0 +1,93 @@
+package matrix
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestKronecker(t *testing.T) {
+	a, x, b := GenerateTrueRandom(rand.Reader, 24)[:16], GenerateTrueRandom(rand.Reader, 32)[:24], GenerateTrueRandom(rand.Reader, 32)
+	for i, row := range b {
+		b[i] = row[:1]
+	}
+
+	if !Unvec(x.Vec(), 24, 32).Equals(x) {
+		t.Fatal("Unvec(X.Vec()) != X")
+	} else if !a.Compose(x).Compose(b).Vec().Equals(Kronecker(b.Transpose(), a).Mul(x.Vec())) {
+		t.Fatal("(A X B).Vec() != (B^T (x) A) X.Vec()")
+	}
+}
+
+func TestSolveSylvester(t *testing.T) {
+	unipotent := GenerateIdentity(16)
+	unipotent[0].SetBit(1, true)
+	unipotent[2].SetBit(3, true)
+
+	b := GenerateTrueRandom(rand.Reader, 16)
+	p, pInv := GenerateRandomWithInverse(rand.Reader, 16)
+
+	cases := [][2]Matrix{
+		{p.Compose(b).Compose(pInv), b},
+		{GenerateIdentity(16), GenerateIdentity(16)},
+		{unipotent, GenerateIdentity(16)},
+		{unipotent, unipotent},
+		{GenerateTrueRandom(rand.Reader, 16), GenerateTrueRandom(rand.Reader, 16)},
+	}
+
+	for _, c := range cases {
+		a, b := c[0], c[1]
+		basis := SolveSylvester(a, b)
+
+		// The solution space is the kernel of (I (x) a + b^T (x) I) acting on X.Vec().
+		system := Kronecker(GenerateIdentity(16), a).Add(Kronecker(b.Transpose(), GenerateIdentity(16)))
+		if len(basis) != system.Kernel().Dim() {
+			t.Fatalf("SolveSylvester returned %v solutions, not %v.", len(basis), system.Kernel().Dim())
+		}
+
+		vecs := []Row{}
+		for _, x := range basis {
+			if !a.Compose(x).Equals(x.Compose(b)) {
+				t.Fatal("SolveSylvester returned a non-solution.")
+			}
+
+			vecs = append(vecs, x.Vec())
+		}
+
+		if NewSubspace(256, vecs...).Dim() != len(basis) {
+			t.Fatal("SolveSylvester returned dependent solutions.")
+		}
+	}
+}
+
+func TestSolveAXB(t *testing.T) {
+	a, b := GenerateTrueRandom(rand.Reader, 32)[:24], GenerateTrueRandom(rand.Reader, 16)
+	a[3], b[5] = a[1].Add(a[2]), b[6]
+	x0 := GenerateTrueRandom(rand.Reader, 32)[:32]
+	for i, row := range x0 {
+		x0[i] = row[:2]
+	}
+
+	c := a.Compose(x0).Compose(b)
+	x, basis, err := SolveAXB(a, b, c)
+	if err != nil {
+		t.Fatalf("SolveAXB returned error on consistent system: %v", err)
+	} else if !a.Compose(x).Compose(b).Equals(c) {
+		t.Fatal("SolveAXB returned a non-solution.")
+	} else if len(basis) != 32*16-a.Rank()*b.Rank() {
+		t.Fatalf("SolveAXB returned %v homogeneous solutions, not %v.", len(basis), 32*16-a.Rank()*b.Rank())
+	}
+
+	for _, y := range basis {
+		if !a.Compose(y).Compose(b).Equals(GenerateEmpty(24, 16)) {
+			t.Fatal("SolveAXB returned a non-solution to the homogeneous equation.")
+		}
+	}
+
+	// Row 3 of a * X * b is always the sum of rows 1 and 2.
+	c[3].SetBit(0, c[3].GetBit(0) == 0)
+	if _, _, err := SolveAXB(a, b, c); err != ErrNoSolution {
+		t.Fatalf("SolveAXB didn't report inconsistent system: %v", err)
+	} else if _, _, err := SolveAXB(a, b, GenerateEmpty(16, 16)); err != ErrDimensionMismatch {
+		t.Fatalf("SolveAXB didn't report dimension mismatch: %v", err)
+	}
+}
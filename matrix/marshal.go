@@ -0,0 +1,247 @@
+package matrix
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/OpenWhiteBox/primitives/internal/parse"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is the number of rows and columns as big-endian
+// 32-bit integers, followed by the bytes of each row.
+//
+// Rows are stored in whole bytes, so a matrix's number of columns is always a multiple of 8--a row made by NewRow(5)
+// has 8 columns--and that's the number written.
+func (e Matrix) MarshalBinary() ([]byte, error) {
+	n, m := e.Size()
+	out := make([]byte, 8, 8+n*rowsToColumns(m))
+
+	binary.BigEndian.PutUint32(out[0:4], uint32(n))
+	binary.BigEndian.PutUint32(out[4:8], uint32(m))
+
+	for _, row := range e {
+		out = append(out, row...)
+	}
+
+	return out, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It reads the format written by MarshalBinary. The number of
+// columns doesn't have to be a multiple of 8, but the bits past it in each row's last byte have to be zero.
+func (e *Matrix) UnmarshalBinary(data []byte) error {
+	if len(data) < 8 {
+		return fmt.Errorf("%w: binary matrix is missing its header", ErrMalformed)
+	}
+
+	n, m := uint64(binary.BigEndian.Uint32(data[0:4])), uint64(binary.BigEndian.Uint32(data[4:8]))
+	width := (m + 7) / 8
+	data = data[8:]
+
+	// Both are less than 2^32, so the product can't overflow.
+	if m == 0 && n != 0 {
+		return fmt.Errorf("%w: binary matrix has %v rows but no columns", ErrMalformed, n)
+	} else if n*width != uint64(len(data)) {
+		return fmt.Errorf("%w: %vx%v binary matrix has %v bytes of rows, not %v", ErrMalformed, n, m, len(data), n*width)
+	}
+
+	rows := make([]Row, n)
+	for i, _ := range rows {
+		rows[i] = Row(data[uint64(i)*width : uint64(i+1)*width])
+	}
+
+	out, err := fromRows(rows, int(m))
+	if err != nil {
+		return err
+	}
+
+	*e = out
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The first line is the number of rows and columns, separated by a
+// space, and each following line is the bytes of one row in hex. As with MarshalBinary, the number of columns is a
+// multiple of 8.
+func (e Matrix) MarshalText() ([]byte, error) {
+	n, m := e.Size()
+	out := fmt.Sprintf("%v %v\n", n, m)
+
+	for _, row := range e {
+		out += hex.EncodeToString(row) + "\n"
+	}
+
+	return []byte(out), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It reads the format written by MarshalText.
+func (e *Matrix) UnmarshalText(text []byte) error {
+	lines := strings.Split(strings.TrimSpace(string(text)), "\n")
+
+	var n, m int
+	if _, err := fmt.Sscanf(lines[0], "%d %d", &n, &m); err != nil {
+		return fmt.Errorf("%w: text matrix has bad header %q", ErrMalformed, lines[0])
+	} else if n < 0 || m < 0 {
+		return fmt.Errorf("%w: text matrix has negative dimensions %vx%v", ErrMalformed, n, m)
+	} else if m == 0 && n != 0 {
+		return fmt.Errorf("%w: text matrix has %v rows but no columns", ErrMalformed, n)
+	} else if len(lines)-1 != n {
+		return fmt.Errorf("%w: text matrix has %v rows, not %v", ErrMalformed, len(lines)-1, n)
+	}
+
+	rows := make([]Row, n)
+	for i, line := range lines[1:] {
+		row, err := hex.DecodeString(strings.TrimSpace(line))
+		if err != nil {
+			return fmt.Errorf("%w: row %v: %v", ErrMalformed, i, err)
+		}
+
+		rows[i] = row
+	}
+
+	out, err := fromRows(rows, m)
+	if err != nil {
+		return err
+	}
+
+	*e = out
+	return nil
+}
+
+// fromRows copies decoded rows into a matrix with m columns, checking that each row is the right length and has no bits
+// set past column m. The rows are checked before anything is allocated, so a bad header can't cause a huge allocation.
+func fromRows(rows []Row, m int) (Matrix, error) {
+	width := rowsToColumns(m)
+
+	for i, row := range rows {
+		if len(row) != width {
+			return nil, fmt.Errorf("%w: row %v has %v bytes, not %v", ErrMalformed, i, len(row), width)
+		} else if m%8 != 0 && row[width-1]>>uint(m%8) != 0 {
+			return nil, fmt.Errorf("%w: row %v has bits set past column %v", ErrMalformed, i, m)
+		}
+	}
+
+	out := GenerateEmpty(len(rows), m)
+	for i, row := range rows {
+		copy(out[i], row)
+	}
+
+	return out, nil
+}
+
+// SageString converts the matrix into a string that can be imported into Sage.
+func (e Matrix) SageString() string {
+	_, m := e.Size()
+	rows := []string{}
+
+	for _, row := range e {
+		bits := []string{}
+		for j := 0; j < m; j++ {
+			bits = append(bits, strconv.Itoa(int(row.GetBit(j))))
+		}
+
+		rows = append(rows, "["+strings.Join(bits, ",")+"]")
+	}
+
+	return "matrix(GF(2), [" + strings.Join(rows, ",\n") + "])"
+}
+
+// ParseOctave parses a matrix in the format written by OctaveString: one row per line, with entries separated by
+// spaces. Octave's bracketed form, like "[0 1; 1 0]", is also accepted.
+func ParseOctave(s string) (Matrix, error) {
+	rows, err := parse.Octave(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	return fromEntries(rows, func(tok string) (byte, error) {
+		switch tok {
+		case "0":
+			return 0, nil
+		case "1":
+			return 1, nil
+		default:
+			return 0, fmt.Errorf("%w: %q isn't a bit", ErrMalformed, tok)
+		}
+	})
+}
+
+// ParseGoString parses a matrix in the format written by GoString: a composite literal where each row is a list of
+// bytes.
+func ParseGoString(s string) (Matrix, error) {
+	rows, err := parse.GoString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	out := Matrix{}
+	for i, toks := range rows {
+		row := NewRow(8 * len(toks))
+
+		for j, tok := range toks {
+			b, err := strconv.ParseUint(tok, 0, 8)
+			if err != nil {
+				return nil, fmt.Errorf("%w: row %v: %v", ErrMalformed, i, err)
+			}
+
+			row[j] = byte(b)
+		}
+
+		out = append(out, row)
+	}
+
+	for i, row := range out {
+		if len(row) != len(out[0]) {
+			return nil, fmt.Errorf("%w: row %v has %v bytes, not %v", ErrMalformed, i, len(row), len(out[0]))
+		}
+	}
+
+	return out, nil
+}
+
+// ParseSage parses a matrix written in Sage's syntax, either as a list of rows, like "matrix(GF(2), [[0,1],[1,0]])",
+// or as dimensions and a flat list of entries, like "matrix(GF(2), 2, 2, [0,1,1,0])". Integer entries are reduced mod
+// 2, as Sage would.
+func ParseSage(s string) (Matrix, error) {
+	field, rows, err := parse.Sage(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	} else if strings.Join(strings.Fields(field), "") != "GF(2)" {
+		return nil, fmt.Errorf("%w: %q isn't GF(2)", ErrMalformed, field)
+	}
+
+	return fromEntries(rows, func(tok string) (byte, error) {
+		x, err := strconv.ParseInt(tok, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q isn't an integer", ErrMalformed, tok)
+		}
+
+		return byte(x & 1), nil
+	})
+}
+
+// fromEntries builds a matrix out of rows of tokens, parsing each token into a bit.
+func fromEntries(rows [][]string, parseEntry func(string) (byte, error)) (Matrix, error) {
+	out := Matrix{}
+
+	for i, toks := range rows {
+		if len(toks) != len(rows[0]) {
+			return nil, fmt.Errorf("%w: row %v has %v entries, not %v", ErrMalformed, i, len(toks), len(rows[0]))
+		}
+
+		row := NewRow(len(toks))
+		for j, tok := range toks {
+			b, err := parseEntry(tok)
+			if err != nil {
+				return nil, err
+			}
+
+			row.SetBit(j, b == 1)
+		}
+
+		out = append(out, row)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,116 @@
+package matrix
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestMarshal(t *testing.T) {
+	m := GenerateTrueRandom(rand.Reader, 24)[:16]
+
+	bin, _ := m.MarshalBinary()
+	text, _ := m.MarshalText()
+
+	var fromBin, fromText Matrix
+	if err := fromBin.UnmarshalBinary(bin); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	} else if err := fromText.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	} else if !fromBin.Equals(m) || !fromText.Equals(m) {
+		t.Fatal("Marshaling and unmarshaling didn't give the original matrix.")
+	}
+
+	if err := fromBin.UnmarshalBinary(bin[:len(bin)-1]); err == nil {
+		t.Fatal("UnmarshalBinary accepted truncated input.")
+	}
+
+	parsers := []struct {
+		name  string
+		parse func(string) (Matrix, error)
+		in    string
+	}{
+		{"Octave", ParseOctave, m.OctaveString()},
+		{"Go", ParseGoString, m.GoString()},
+		{"Sage", ParseSage, m.SageString()},
+	}
+
+	for _, p := range parsers {
+		parsed, err := p.parse(p.in)
+		if err != nil {
+			t.Fatalf("Parse%v failed: %v", p.name, err)
+		} else if !parsed.Equals(m) {
+			t.Fatalf("Parse%v didn't give the original matrix.", p.name)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	real := Matrix{Row{0x02}, Row{0x05}}
+
+	cands := []string{
+		"[0 1 0 0 0 0 0 0; 1 0 1 0 0 0 0 0]",
+		"matrix(GF(2), [[0,1,0,0,0,0,0,0], [1,0,1,0,0,0,0,0]])",
+		"matrix(GF(2), 2, 8, [0,1,0,0,0,0,0,0, 1,0,3,0,0,0,0,0])",
+		"matrix(GF(2), 2, [0,1,0,0,0,0,0,0, 1,0,1,0,0,0,0,0])",
+		"Matrix{Row{2}, Row{0x05}}",
+	}
+	parse := []func(string) (Matrix, error){ParseOctave, ParseSage, ParseSage, ParseSage, ParseGoString}
+
+	for i, cand := range cands {
+		m, err := parse[i](cand)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", cand, err)
+		} else if !m.Equals(real) {
+			t.Fatalf("Parsing %q gave the wrong matrix:\n%v", cand, m)
+		}
+	}
+
+	bad := []string{"[0 1; 1]", "[0 2]", "matrix(GF(3), [[0]])", "matrix(GF(2), 3, [0,1])", "matrix(GF(2), [[0,1]"}
+	parse = []func(string) (Matrix, error){ParseOctave, ParseOctave, ParseSage, ParseSage, ParseSage}
+
+	for i, cand := range bad {
+		if _, err := parse[i](cand); err == nil {
+			t.Fatalf("Parsed malformed matrix %q.", cand)
+		}
+	}
+}
+
+func TestUnmarshalMalformed(t *testing.T) {
+	texts := []string{"1 -8\n00", "-1 8\n", "2 0\n\n", "1 99999999999\n00", "1 5\nff"}
+	for _, text := range texts {
+		var m Matrix
+		if err := m.UnmarshalText([]byte(text)); err == nil {
+			t.Fatalf("UnmarshalText accepted malformed matrix %q.", text)
+		}
+	}
+
+	bins := [][]byte{
+		{0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00}, // Rows without columns.
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, // Dimensions whose product overflows 32 bits.
+		{0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x05, 0xff},
+	}
+	for _, bin := range bins {
+		var m Matrix
+		if err := m.UnmarshalBinary(bin); err == nil {
+			t.Fatalf("UnmarshalBinary accepted malformed matrix %x.", bin)
+		}
+	}
+}
+
+func TestMarshalColumns(t *testing.T) {
+	// Rows are stored in whole bytes, so a 5-column row is 8 columns wide and round-trips as such.
+	m := Matrix{NewRow(5), NewRow(5)}
+	m[1].SetBit(4, true)
+
+	text, _ := m.MarshalText()
+	if string(text) != "2 8\n00\n10\n" {
+		t.Fatalf("MarshalText wrote %q.", text)
+	}
+
+	var parsed Matrix
+	if err := parsed.UnmarshalText([]byte("2 5\n00\n10")); err != nil {
+		t.Fatalf("UnmarshalText failed on 5-column matrix: %v", err)
+	} else if !parsed.Equals(m) {
+		t.Fatal("UnmarshalText gave the wrong 5-column matrix.")
+	}
+}
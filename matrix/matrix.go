@@ -19,9 +19,20 @@ type Matrix []Row
 
 // Mul right-multiplies a matrix by a row.
 func (e Matrix) Mul(f Row) Row {
+	res, err := e.CheckedMul(f)
+	if err != nil {
+		panic("Can't multiply by row that is wrong size!")
+	}
+
+	return res
+}
+
+// CheckedMul right-multiplies a matrix by a row. It returns ErrDimensionMismatch instead of panicking if the row is the
+// wrong size.
+func (e Matrix) CheckedMul(f Row) (Row, error) {
 	out, in := e.Size()
 	if in != f.Size() {
-		panic("Can't multiply by row that is wrong size!")
+		return nil, ErrDimensionMismatch
 	}
 
 	res := NewRow(out)
@@ -31,7 +42,7 @@ func (e Matrix) Mul(f Row) Row {
 		}
 	}
 
-	return res
+	return res, nil
 }
 
 // Add adds two binary matrices from GF(2)^nxm.
@@ -48,27 +59,37 @@ func (e Matrix) Add(f Matrix) Matrix {
 
 // Compose returns the result of composing e with f.
 func (e Matrix) Compose(f Matrix) Matrix {
+	out, err := e.CheckedCompose(f)
+	if err != nil {
+		panic("Can't multiply matrices of wrong size!")
+	}
+
+	return out
+}
+
+// CheckedCompose returns the result of composing e with f. It returns ErrDimensionMismatch instead of panicking if the
+// matrices are incompatible sizes.
+func (e Matrix) CheckedCompose(f Matrix) (Matrix, error) {
 	n, m := e.Size()
 	p, q := f.Size()
 
 	if m != p {
-		panic("Can't multiply matrices of wrong size!")
+		return nil, ErrDimensionMismatch
 	}
 
-	out := GenerateEmpty(n, q)
-	g := f.Transpose()
-
-	for i, e_i := range e {
-		for j, g_j := range g {
-			out[i].SetBit(j, e_i.DotProduct(g_j))
-		}
+	if n == 0 || q == 0 {
+		return GenerateEmpty(n, q), nil
 	}
 
-	return out
+	return e.mul(f.pack()).unpack(0, q), nil
 }
 
 // Invert computes the multiplicative inverse of a matrix, if it exists.
 func (e Matrix) Invert() (Matrix, bool) {
+	if n, m := e.Size(); n != m {
+		return nil, false
+	}
+
 	inv, _, frees := e.gaussJordan()
 	return inv, len(frees) == 0
 }
@@ -2,6 +2,7 @@ package matrix
 
 import (
 	"crypto/rand"
+	mrand "math/rand"
 	"testing"
 
 	"github.com/OpenWhiteBox/primitives/number"
@@ -114,6 +115,52 @@ func TestCompose(t *testing.T) {
 	}
 }
 
+func TestComposeRandom(t *testing.T) {
+	// Sizes straddle word and table boundaries.
+	for _, size := range [][3]int{{8, 8, 8}, {72, 136, 40}, {128, 128, 128}, {200, 64, 256}} {
+		n, m, p := size[0], size[1], size[2]
+
+		A, B := GenerateEmpty(n, m), GenerateEmpty(m, p)
+		for i := range A {
+			A[i] = GenerateRandomRow(rand.Reader, m)
+		}
+		for i := range B {
+			B[i] = GenerateRandomRow(rand.Reader, p)
+		}
+
+		AB := A.Compose(B)
+
+		for i := 0; i < 10; i++ {
+			x := GenerateRandomRow(rand.Reader, p)
+
+			if !AB.Mul(x).Equals(A.Mul(B.Mul(x))) {
+				t.Fatalf("Composition of %v-by-%v and %v-by-%v matrices is wrong!", n, m, m, p)
+			}
+		}
+	}
+}
+
+func TestInvertLarge(t *testing.T) {
+	for _, n := range []int{72, 128, 200} {
+		m := GenerateRandom(rand.Reader, n)
+		mInv, ok := m.Invert()
+		if !ok {
+			t.Fatalf("Failed to invert invertible %v-by-%v matrix.", n, n)
+		}
+
+		if !m.Compose(mInv).Equals(GenerateIdentity(n)) || !mInv.Compose(m).Equals(GenerateIdentity(n)) {
+			t.Fatalf("M * M^-1 != M^-1 * M != I for %v-by-%v matrix.", n, n)
+		}
+	}
+
+	m := GenerateRandom(rand.Reader, 128)
+	m[5] = m[3].Add(m[100])
+
+	if _, ok := m.Invert(); ok {
+		t.Fatal("Invert said singular matrix was invertible.")
+	}
+}
+
 func TestRightStretch(t *testing.T) {
 	M := GenerateRandom(rand.Reader, 8)
 	sboxRow := Row{0xF1, 0xE3, 0xC7, 0x8F, 0x1F, 0x3E, 0x7C, 0xF8}
@@ -157,6 +204,25 @@ func TestNullSpace(t *testing.T) {
 	}
 }
 
+func TestNullSpaceWide(t *testing.T) {
+	m := GenerateEmpty(16, 64)
+	for i := range m {
+		m[i] = GenerateRandomRow(rand.Reader, 64)
+	}
+	m[7] = m[2].Add(m[9])
+
+	NS := m.NullSpace()
+	if len(NS) < 64-15 {
+		t.Fatalf("NullSpace returned a basis that's too small: %v", len(NS))
+	}
+
+	for _, n := range NS {
+		if !m.Mul(n).IsZero() {
+			t.Fatalf("Didn't find an actual element of the nullspace!\n x = %x\nMx = %x\n", n, m.Mul(n))
+		}
+	}
+}
+
 func TestTrace(t *testing.T) {
 	m := Matrix{Row{12}, Row{20}, Row{41}, Row{94}, Row{176}, Row{97}, Row{195}, Row{134}}
 	n := Matrix{Row{53}, Row{95}, Row{191}, Row{75}, Row{163}, Row{70}, Row{141}, Row{26}}
@@ -179,3 +245,101 @@ func BenchmarkInvert(b *testing.B) {
 		m.Invert()
 	}
 }
+
+func BenchmarkInvert128(b *testing.B) {
+	m := GenerateRandom(rand.Reader, 128)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.Invert()
+	}
+}
+
+func BenchmarkGenerateRandom(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		GenerateRandomWithInverse(rand.Reader, 128)
+	}
+}
+
+func BenchmarkCompose(b *testing.B) {
+	m, n := GenerateRandom(rand.Reader, 128), GenerateRandom(rand.Reader, 128)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.Compose(n)
+	}
+}
+
+func BenchmarkMul(b *testing.B) {
+	m, x := GenerateRandom(rand.Reader, 128), GenerateRandomRow(rand.Reader, 128)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.Mul(x)
+	}
+}
+
+func BenchmarkNullSpace(b *testing.B) {
+	m := GenerateTrueRandom(rand.Reader, 128)
+	m[0] = m[1].Add(m[2])
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.NullSpace()
+	}
+}
+
+func TestCheckedDimensionMismatch(t *testing.T) {
+	m := GenerateRandom(rand.Reader, 16)
+
+	if _, err := NewRow(16).CheckedAdd(NewRow(8)); err != ErrDimensionMismatch {
+		t.Fatalf("CheckedAdd didn't report dimension mismatch: %v", err)
+	} else if _, err := m.CheckedMul(NewRow(8)); err != ErrDimensionMismatch {
+		t.Fatalf("CheckedMul didn't report dimension mismatch: %v", err)
+	} else if _, err := m.CheckedCompose(GenerateIdentity(8)); err != ErrDimensionMismatch {
+		t.Fatalf("CheckedCompose didn't report dimension mismatch: %v", err)
+	}
+
+	if x, err := m.CheckedMul(NewRow(16)); err != nil || !x.IsZero() {
+		t.Fatal("CheckedMul failed on correctly sized row.")
+	}
+}
+
+func TestGenerateRandomWithInverse(t *testing.T) {
+	m, mInv := GenerateRandomWithInverse(rand.Reader, 128)
+
+	if !m.Compose(mInv).Equals(GenerateIdentity(128)) || !mInv.Compose(m).Equals(GenerateIdentity(128)) {
+		t.Fatal("M * M^-1 != M^-1 * M != I")
+	}
+
+	// The same seed should give the same matrix.
+	x := GenerateRandom(mrand.New(mrand.NewSource(1)), 64)
+	y := GenerateRandom(mrand.New(mrand.NewSource(1)), 64)
+
+	if !x.Equals(y) {
+		t.Fatal("GenerateRandom gave different matrices for the same seed.")
+	}
+}
+
+func TestGenerateRandomUniform(t *testing.T) {
+	// There are 168 invertible 3-by-3 matrices; each should be generated about equally often.
+	counts := make(map[[3]byte]int)
+	for i := 0; i < 168*200; i++ {
+		m, _ := GenerateRandomWithInverse(rand.Reader, 3)
+		counts[[3]byte{m[0][0], m[1][0], m[2][0]}]++
+	}
+
+	if len(counts) != 168 {
+		t.Fatalf("Generated %v distinct invertible 3-by-3 matrices, wanted 168.", len(counts))
+	}
+
+	for m, count := range counts {
+		if count < 100 || count > 300 {
+			t.Fatalf("Matrix %x was generated %v times out of an expected 200.", m, count)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package matrix
+
+import (
+	"math/bits"
+)
+
+// m4rK is the number of rows/columns handled at once by the Method of Four Russians. With eight, every byte of a Row
+// indexes a table directly.
+const m4rK = 8
+
+// words is a matrix packed into 64-bit words, used internally for fast arithmetic. Bit j of row i is stored in bit j%64
+// of word j/64 of that row, which is the same bit order a Row uses.
+type words struct {
+	rows, cols int
+	stride     int // The number of words in each row.
+	data       []uint64
+}
+
+// newWords returns an empty rows-by-cols packed matrix.
+func newWords(rows, cols int) *words {
+	stride := (cols + 63) / 64
+
+	return &words{
+		rows:   rows,
+		cols:   cols,
+		stride: stride,
+		data:   make([]uint64, rows*stride),
+	}
+}
+
+// row returns the words of the ith row.
+func (w *words) row(i int) []uint64 {
+	return w.data[i*w.stride : (i+1)*w.stride]
+}
+
+// bit returns the entry at row i and column j: 0 or 1.
+func (w *words) bit(i, j int) uint64 {
+	return (w.data[i*w.stride+j/64] >> uint(j%64)) & 1
+}
+
+// bits returns the n <= 8 entries of row i starting at column j, packed into an int.
+func (w *words) bits(i, j, n int) int {
+	r, shift := w.row(i), uint(j%64)
+
+	x := r[j/64] >> shift
+	if shift > 64-uint(n) && j/64+1 < len(r) {
+		x |= r[j/64+1] << (64 - shift)
+	}
+
+	return int(x & (1<<uint(n) - 1))
+}
+
+// flip flips the entry at row i and column j.
+func (w *words) flip(i, j int) {
+	w.data[i*w.stride+j/64] ^= 1 << uint(j%64)
+}
+
+// swap swaps rows i and j.
+func (w *words) swap(i, j int) {
+	if i == j {
+		return
+	}
+
+	a, b := w.row(i), w.row(j)
+	for k := range a {
+		a[k], b[k] = b[k], a[k]
+	}
+}
+
+// xorRow adds src into dst, starting at word from.
+func xorRow(dst, src []uint64, from int) {
+	for k := from; k < len(dst); k++ {
+		dst[k] ^= src[k]
+	}
+}
+
+// packRow adds the bits of src into dst, starting at bit offset.
+func packRow(dst []uint64, src Row, offset int) {
+	for k, b := range src {
+		if b == 0 {
+			continue
+		}
+
+		pos := offset + 8*k
+		word, shift := pos/64, uint(pos%64)
+
+		dst[word] |= uint64(b) << shift
+		if hi := uint64(b) >> (64 - shift); shift > 56 && hi != 0 {
+			dst[word+1] |= hi
+		}
+	}
+}
+
+// unpackRow fills dst with n bits from src, starting at bit offset.
+func unpackRow(dst Row, src []uint64, offset, n int) {
+	for k := range dst {
+		pos := offset + 8*k
+		word, shift := pos/64, uint(pos%64)
+
+		b := src[word] >> shift
+		if shift > 56 && word+1 < len(src) {
+			b |= src[word+1] << (64 - shift)
+		}
+
+		dst[k] = byte(b)
+	}
+
+	if n%8 != 0 {
+		dst[len(dst)-1] &= byte(1<<uint(n%8)) - 1
+	}
+}
+
+// pack converts a matrix into its packed form.
+func (e Matrix) pack() *words {
+	n, m := e.Size()
+	w := newWords(n, m)
+
+	for i, row := range e {
+		packRow(w.row(i), row, 0)
+	}
+
+	return w
+}
+
+// unpack converts the n columns of w starting at column offset back into a Matrix.
+func (w *words) unpack(offset, n int) Matrix {
+	out := GenerateEmpty(w.rows, n)
+
+	for i, row := range out {
+		unpackRow(row, w.row(i), offset, n)
+	}
+
+	return out
+}
+
+// transpose returns the transpose of w as a Matrix.
+func (w *words) transpose() Matrix {
+	out := GenerateEmpty(w.cols, w.rows)
+
+	for i := 0; i < w.rows; i++ {
+		for k, word := range w.row(i) {
+			for word != 0 {
+				j := 64*k + bits.TrailingZeros64(word)
+				out[j][i/8] |= 1 << uint(i%8)
+
+				word &= word - 1
+			}
+		}
+	}
+
+	return out
+}
+
+// mul returns the product of the packed matrices e and f with the Method of Four Russians: the rows of f are taken
+// m4rK at a time, every combination of them is tabulated, and each row of e selects one combination with one byte.
+func (e Matrix) mul(f *words) *words {
+	n, _ := e.Size()
+	out := newWords(n, f.cols)
+	table := make([]uint64, (1<<m4rK)*f.stride)
+
+	for g := 0; g*m4rK < f.rows; g++ {
+		// Tabulate every combination of the rows of f in this group. table[x] = table[x - lowbit(x)] + row.
+		top := f.rows - g*m4rK
+		if top > m4rK {
+			top = m4rK
+		}
+
+		for x := 1; x < 1<<uint(top); x++ {
+			k := bits.TrailingZeros(uint(x))
+			dst, prev := table[x*f.stride:(x+1)*f.stride], table[(x&(x-1))*f.stride:]
+			src := f.row(g*m4rK + k)
+
+			for j := range dst {
+				dst[j] = prev[j] ^ src[j]
+			}
+		}
+
+		// Look up the combination each row of e asks for.
+		for i, e_i := range e {
+			x := int(e_i[g])
+			if x == 0 {
+				continue
+			}
+
+			xorRow(out.row(i), table[x*f.stride:(x+1)*f.stride], 0)
+		}
+	}
+
+	return out
+}
+
+// reduce puts the first n columns of w into reduced row echelon form with the Method of Four Russians, applying every
+// row operation to the rest of the columns as well. It returns the pivot columns in increasing order.
+//
+// Pivots are found m4rK at a time with plain Gaussian elimination on a strip of rows. Then, every combination of the
+// strip's rows is tabulated, so that the strip's columns can be cleared in every other row with one lookup.
+func (w *words) reduce(n int) (pivots []int) {
+	table := make([]uint64, (1<<m4rK)*w.stride)
+	strip := make([]int, 0, m4rK)
+
+	row, col := 0, 0
+
+	for col < n && row < w.rows {
+		start := row
+		strip = strip[:0]
+
+		for ; col < n && row < w.rows && len(strip) < m4rK; col++ {
+			i := w.findPivot(row, col, start, strip)
+			if i == -1 { // Failed to find a pivot.
+				continue
+			}
+
+			// Move it into position and cancel this column out of the strip's other rows.
+			w.swap(row, i)
+			for k := range strip {
+				if w.bit(start+k, col) == 1 {
+					xorRow(w.row(start+k), w.row(row), 0)
+				}
+			}
+
+			strip = append(strip, col)
+			row++
+		}
+
+		if len(strip) == 0 {
+			break
+		}
+
+		// Every column before the strip's first pivot is zero in the strip's rows, so those words can be skipped.
+		from := strip[0] / 64
+
+		for x := 1; x < 1<<uint(len(strip)); x++ {
+			k := bits.TrailingZeros(uint(x))
+			dst, prev := table[x*w.stride:(x+1)*w.stride], table[(x&(x-1))*w.stride:]
+			src := w.row(start + k)
+
+			for j := from; j < w.stride; j++ {
+				dst[j] = prev[j] ^ src[j]
+			}
+		}
+
+		// If the pivots are in consecutive columns, as they are for invertible matrices, look them up all at once.
+		contiguous := strip[len(strip)-1]-strip[0] == len(strip)-1
+
+		for j := 0; j < w.rows; j++ {
+			if start <= j && j < row {
+				continue
+			}
+
+			x := 0
+			if contiguous {
+				x = w.bits(j, strip[0], len(strip))
+			} else {
+				for k, p := range strip {
+					x |= int(w.bit(j, p)) << uint(k)
+				}
+			}
+
+			if x != 0 {
+				xorRow(w.row(j), table[x*w.stride:(x+1)*w.stride], from)
+			}
+		}
+
+		pivots = append(pivots, strip...)
+	}
+
+	return
+}
+
+// findPivot finds a row with a non-zero entry in column col, starting at the given row and moving down. Rows are reduced
+// by the pivots already found in the current strip as they're searched. It returns the index of the row or -1 if one
+// doesn't exist.
+func (w *words) findPivot(row, col, start int, strip []int) int {
+	for i := row; i < w.rows; i++ {
+		for k, p := range strip {
+			if w.bit(i, p) == 1 {
+				xorRow(w.row(i), w.row(start+k), 0)
+			}
+		}
+
+		if w.bit(i, col) == 1 {
+			return i
+		}
+	}
+
+	return -1
+}
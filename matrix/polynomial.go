@@ -0,0 +1,488 @@
+package matrix
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Polynomial is a polynomial over GF(2). Bit i, in the same order as the bits of a Row, is the coefficient of x^i.
+// Polynomials are kept trimmed, so the zero polynomial is empty.
+type Polynomial []byte
+
+// Factor is an irreducible factor of a polynomial and its multiplicity.
+type Factor struct {
+	Poly         Polynomial
+	Multiplicity int
+}
+
+// NewPolynomial returns the polynomial with a coefficient of 1 at each of the given degrees. For example, Rijndael's
+// polynomial is NewPolynomial(8, 4, 3, 1, 0).
+func NewPolynomial(degrees ...int) Polynomial {
+	p := Polynomial{}
+
+	for _, d := range degrees {
+		p = p.Add(monomial(d))
+	}
+
+	return p
+}
+
+// monomial returns x^d.
+func monomial(d int) Polynomial {
+	p := make(Polynomial, d/8+1)
+	p[d/8] = 1 << uint(d%8)
+
+	return p
+}
+
+// trim removes leading zero bytes.
+func (p Polynomial) trim() Polynomial {
+	for len(p) > 0 && p[len(p)-1] == 0 {
+		p = p[:len(p)-1]
+	}
+
+	return p
+}
+
+// coefficient returns the coefficient of x^i: 0 or 1.
+func (p Polynomial) coefficient(i int) byte {
+	if i/8 >= len(p) {
+		return 0
+	}
+
+	return (p[i/8] >> uint(i%8)) & 1
+}
+
+// Degree returns the degree of the polynomial, or -1 if it's zero.
+func (p Polynomial) Degree() int {
+	p = p.trim()
+	if len(p) == 0 {
+		return -1
+	}
+
+	top := p[len(p)-1]
+	d := 8*len(p) - 1
+	for top&0x80 == 0 {
+		top <<= 1
+		d--
+	}
+
+	return d
+}
+
+// IsZero returns true if the polynomial is zero.
+func (p Polynomial) IsZero() bool {
+	return p.Degree() == -1
+}
+
+// IsOne returns true if the polynomial is the constant 1.
+func (p Polynomial) IsOne() bool {
+	return p.Degree() == 0
+}
+
+// Equals returns true if two polynomials are equal and false otherwise.
+func (p Polynomial) Equals(q Polynomial) bool {
+	p, q = p.trim(), q.trim()
+	if len(p) != len(q) {
+		return false
+	}
+
+	for i, _ := range p {
+		if p[i] != q[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// less orders polynomials by degree, and then by their coefficients read as binary numbers.
+func (p Polynomial) less(q Polynomial) bool {
+	p, q = p.trim(), q.trim()
+	if len(p) != len(q) {
+		return len(p) < len(q)
+	}
+
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i] != q[i] {
+			return p[i] < q[i]
+		}
+	}
+
+	return false
+}
+
+// Add returns p + q.
+func (p Polynomial) Add(q Polynomial) Polynomial {
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+
+	out := make(Polynomial, len(p))
+	copy(out, p)
+
+	for i, b := range q {
+		out[i] ^= b
+	}
+
+	return out.trim()
+}
+
+// Mul returns p * q.
+func (p Polynomial) Mul(q Polynomial) Polynomial {
+	dp, dq := p.Degree(), q.Degree()
+	if dp == -1 || dq == -1 {
+		return Polynomial{}
+	}
+
+	out := make(Polynomial, (dp+dq)/8+1)
+
+	for i := 0; i <= dp; i++ {
+		if p.coefficient(i) == 1 {
+			out.addShifted(q, i)
+		}
+	}
+
+	return out.trim()
+}
+
+// addShifted adds q * x^s into p in place. p must be long enough to hold the result.
+func (p Polynomial) addShifted(q Polynomial, s int) {
+	for j := 0; j <= q.Degree(); j++ {
+		if q.coefficient(j) == 1 {
+			p[(j+s)/8] ^= 1 << uint((j+s)%8)
+		}
+	}
+}
+
+// DivMod returns the quotient and remainder of dividing p by q. It panics if q is zero.
+func (p Polynomial) DivMod(q Polynomial) (quo, rem Polynomial) {
+	dq := q.Degree()
+	if dq == -1 {
+		panic("Can't divide by zero polynomial!")
+	}
+
+	rem = p.Add(Polynomial{})
+	dr := rem.Degree()
+	if dr < dq {
+		return Polynomial{}, rem
+	}
+
+	quo = make(Polynomial, (dr-dq)/8+1)
+
+	for ; dr >= dq; dr = rem.Degree() {
+		quo[(dr-dq)/8] ^= 1 << uint((dr-dq)%8)
+		rem.addShifted(q, dr-dq)
+		rem = rem.trim()
+	}
+
+	return quo.trim(), rem
+}
+
+// Mod returns p mod q.
+func (p Polynomial) Mod(q Polynomial) Polynomial {
+	_, rem := p.DivMod(q)
+	return rem
+}
+
+// GCD returns the greatest common divisor of p and q.
+func (p Polynomial) GCD(q Polynomial) Polynomial {
+	for !q.IsZero() {
+		p, q = q, p.Mod(q)
+	}
+
+	return p.trim()
+}
+
+// Derivative returns the formal derivative of p.
+func (p Polynomial) Derivative() Polynomial {
+	out := make(Polynomial, len(p))
+
+	// Over GF(2), the derivative of x^i is x^(i-1) when i is odd and zero when i is even.
+	for i := 1; i <= p.Degree(); i += 2 {
+		if p.coefficient(i) == 1 {
+			out[(i-1)/8] ^= 1 << uint((i-1)%8)
+		}
+	}
+
+	return out.trim()
+}
+
+// Eval returns the polynomial evaluated at a square matrix.
+func (p Polynomial) Eval(e Matrix) Matrix {
+	n, _ := e.Size()
+	out := GenerateEmpty(n, n)
+
+	// Horner's method.
+	for i := p.Degree(); i >= 0; i-- {
+		out = out.Compose(e)
+
+		if p.coefficient(i) == 1 {
+			for j := 0; j < n; j++ {
+				out[j].SetBit(j, out[j].GetBit(j) == 0)
+			}
+		}
+	}
+
+	return out
+}
+
+// expMod returns x^k mod f.
+func expMod(k *big.Int, f Polynomial) Polynomial {
+	out, x := NewPolynomial(0).Mod(f), NewPolynomial(1).Mod(f)
+
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		out = out.Mul(out).Mod(f)
+		if k.Bit(i) == 1 {
+			out = out.Mul(x).Mod(f)
+		}
+	}
+
+	return out
+}
+
+// Factor returns the irreducible factors of a non-zero polynomial and their multiplicities, ordered by degree and then by
+// coefficients.
+func (p Polynomial) Factor() (factors []Factor) {
+	if p.IsZero() {
+		panic("Can't factor zero polynomial!")
+	}
+
+	for _, sf := range p.squareFree() {
+		for _, f := range sf.Poly.berlekamp() {
+			factors = append(factors, Factor{f, sf.Multiplicity})
+		}
+	}
+
+	sort.Slice(factors, func(i, j int) bool { return factors[i].Poly.less(factors[j].Poly) })
+
+	return
+}
+
+// IsIrreducible returns true if the polynomial has no non-trivial factors.
+func (p Polynomial) IsIrreducible() bool {
+	if p.Degree() < 1 {
+		return false
+	}
+
+	factors := p.Factor()
+	return len(factors) == 1 && factors[0].Multiplicity == 1
+}
+
+// squareFree splits p into square-free polynomials, each paired with the multiplicity that its factors have in p.
+func (p Polynomial) squareFree() (out []Factor) {
+	p = p.trim()
+	if p.Degree() < 1 {
+		return nil
+	}
+
+	d := p.Derivative()
+	if d.IsZero() {
+		// p is a square. Its square root has the even coefficients of p.
+		root := Polynomial{}
+		for i := 0; i <= p.Degree(); i += 2 {
+			if p.coefficient(i) == 1 {
+				root = root.Add(monomial(i / 2))
+			}
+		}
+
+		for _, f := range root.squareFree() {
+			out = append(out, Factor{f.Poly, 2 * f.Multiplicity})
+		}
+
+		return
+	}
+
+	// Strip off the factors of p whose multiplicity is odd, one multiplicity at a time. What's left is a square.
+	g := p.GCD(d)
+	w, _ := p.DivMod(g)
+
+	for i := 1; !w.IsOne(); i++ {
+		y := w.GCD(g)
+		if z, _ := w.DivMod(y); !z.IsOne() {
+			out = append(out, Factor{z, i})
+		}
+
+		w = y
+		g, _ = g.DivMod(y)
+	}
+
+	for _, f := range g.squareFree() {
+		out = append(out, f)
+	}
+
+	return
+}
+
+// berlekamp returns the irreducible factors of a square-free polynomial with Berlekamp's algorithm.
+func (p Polynomial) berlekamp() []Polynomial {
+	n := p.Degree()
+	if n <= 1 {
+		return []Polynomial{p.trim()}
+	}
+
+	// Find every v of degree less than n such that v^2 = v (mod p). The columns past n only hold the identity, forcing
+	// those coordinates to zero.
+	m := rowsToColumns(n) * 8
+	cols := GenerateIdentity(m)
+
+	for i := 0; i < n; i++ {
+		sq := monomial(2 * i).Mod(p)
+		for j := 0; j < n; j++ {
+			if sq.coefficient(j) == 1 {
+				cols[i].SetBit(j, cols[i].GetBit(j) == 0)
+			}
+		}
+	}
+
+	// Every v splits each factor f into gcd(f, v) and gcd(f, v+1).
+	factors := []Polynomial{p.trim()}
+	basis := cols.Transpose().Kernel().Basis()
+
+	for _, row := range basis {
+		v := Polynomial(row.Dup()).trim()
+		if v.Degree() < 1 {
+			continue
+		}
+
+		next := []Polynomial{}
+		for _, f := range factors {
+			a := f.GCD(v)
+			if a.Degree() < 1 || a.Degree() == f.Degree() {
+				next = append(next, f)
+				continue
+			}
+
+			b, _ := f.DivMod(a)
+			next = append(next, a, b)
+		}
+
+		factors = next
+		if len(factors) == len(basis) {
+			break
+		}
+	}
+
+	return factors
+}
+
+// order returns the multiplicative order of x modulo an irreducible polynomial f other than x.
+func order(f Polynomial) *big.Int {
+	// The order divides 2^d - 1. Divide out each prime factor for as long as x stays a root of unity.
+	d := f.Degree()
+	n := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(d)), big.NewInt(1))
+
+	out := new(big.Int).Set(n)
+	for _, q := range primeFactors(n) {
+		for {
+			quo, rem := new(big.Int).QuoRem(out, q, new(big.Int))
+			if rem.Sign() != 0 || !expMod(quo, f).IsOne() {
+				break
+			}
+
+			out = quo
+		}
+	}
+
+	return out
+}
+
+// primeFactors returns the distinct prime factors of n, with trial division and then Pollard's rho.
+func primeFactors(n *big.Int) (out []*big.Int) {
+	n = new(big.Int).Set(n)
+	one := big.NewInt(1)
+
+	for p := int64(2); p < 1<<12 && n.Cmp(one) > 0; p++ {
+		q := big.NewInt(p)
+		if new(big.Int).Mod(n, q).Sign() != 0 {
+			continue
+		}
+
+		out = append(out, q)
+		for new(big.Int).Mod(n, q).Sign() == 0 {
+			n.Quo(n, q)
+		}
+	}
+
+	stack := []*big.Int{}
+	if n.Cmp(one) > 0 {
+		stack = append(stack, n)
+	}
+
+	for len(stack) > 0 {
+		m := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if m.ProbablyPrime(20) {
+			dup := false
+			for _, q := range out {
+				dup = dup || q.Cmp(m) == 0
+			}
+			if !dup {
+				out = append(out, m)
+			}
+
+			continue
+		}
+
+		d := pollardRho(m)
+		stack = append(stack, d, new(big.Int).Quo(m, d))
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Cmp(out[j]) < 0 })
+
+	return
+}
+
+// pollardRho returns a non-trivial factor of a composite n.
+func pollardRho(n *big.Int) *big.Int {
+	one := big.NewInt(1)
+
+	for c := int64(1); ; c++ {
+		x, y, d := big.NewInt(2), big.NewInt(2), big.NewInt(1)
+		step := func(z *big.Int) {
+			z.Mul(z, z).Add(z, big.NewInt(c)).Mod(z, n)
+		}
+
+		for d.Cmp(one) == 0 {
+			step(x)
+			step(y)
+			step(y)
+
+			d.Sub(x, y).Abs(d)
+			d.GCD(nil, nil, d, n)
+		}
+
+		if d.Cmp(n) != 0 {
+			return d
+		}
+	}
+}
+
+// String converts the polynomial to a human-readable form, like "x^8 + x^4 + x^3 + x + 1".
+func (p Polynomial) String() string {
+	terms := []string{}
+
+	for i := p.Degree(); i >= 0; i-- {
+		if p.coefficient(i) == 0 {
+			continue
+		}
+
+		switch i {
+		case 0:
+			terms = append(terms, "1")
+		case 1:
+			terms = append(terms, "x")
+		default:
+			terms = append(terms, fmt.Sprintf("x^%v", i))
+		}
+	}
+
+	if len(terms) == 0 {
+		return "0"
+	}
+
+	return strings.Join(terms, " + ")
+}
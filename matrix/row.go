@@ -2,14 +2,11 @@ package matrix
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
+	"math/bits"
 )
 
-var weight [4]uint64 = [4]uint64{
-	0x6996966996696996, 0x9669699669969669,
-	0x9669699669969669, 0x6996966996696996,
-}
-
 // A binary row / vector in GF(2)^n.
 type Row []byte
 
@@ -44,9 +41,19 @@ func LessThan(i, j Row) bool {
 
 // Add adds (XORs) two vectors.
 func (e Row) Add(f Row) Row {
+	out, err := e.CheckedAdd(f)
+	if err != nil {
+		panic("Can't add rows that are different sizes!")
+	}
+
+	return out
+}
+
+// CheckedAdd adds (XORs) two vectors. It returns ErrDimensionMismatch instead of panicking if they're different sizes.
+func (e Row) CheckedAdd(f Row) (Row, error) {
 	le, lf := len(e), len(f)
 	if le != lf {
-		panic("Can't add rows that are different sizes!")
+		return nil, ErrDimensionMismatch
 	}
 
 	out := make([]byte, le)
@@ -54,7 +61,7 @@ func (e Row) Add(f Row) Row {
 		out[i] = e[i] ^ f[i]
 	}
 
-	return Row(out)
+	return Row(out), nil
 }
 
 // Mul component-wise multiplies (ANDs) two vectors.
@@ -74,13 +81,22 @@ func (e Row) Mul(f Row) Row {
 
 // DotProduct computes the dot product of two vectors.
 func (e Row) DotProduct(f Row) bool {
-	parity := uint64(0)
+	le, lf := len(e), len(f)
+	if le != lf {
+		panic("Can't multiply rows that are different sizes!")
+	}
+
+	// Accumulate the component-wise product a word at a time and take the parity at the end.
+	acc, i := uint64(0), 0
+	for ; i+8 <= le; i += 8 {
+		acc ^= binary.LittleEndian.Uint64(e[i:]) & binary.LittleEndian.Uint64(f[i:])
+	}
 
-	for _, g_i := range e.Mul(f) {
-		parity ^= (weight[g_i/64] >> (g_i % 64)) & 1
+	for ; i < le; i++ {
+		acc ^= uint64(e[i] & f[i])
 	}
 
-	return parity == 1
+	return bits.OnesCount64(acc)&1 == 1
 }
 
 // Weight returns the hamming weight of this row.
@@ -0,0 +1,250 @@
+package matrix
+
+import (
+	"math/big"
+)
+
+// CharPoly returns the characteristic polynomial of a square matrix, det(xI + M).
+func (e Matrix) CharPoly() Polynomial {
+	n, m := e.Size()
+	if n != m {
+		panic("Can't take characteristic polynomial of non-square matrix!")
+	}
+
+	h := e.hessenberg()
+
+	// p[k] is the characteristic polynomial of the top-left k-by-k submatrix of h.
+	p := make([]Polynomial, n+1)
+	p[0] = NewPolynomial(0)
+
+	for k := 1; k <= n; k++ {
+		p[k] = p[k-1].Mul(NewPolynomial(1))
+		if h[k-1].GetBit(k-1) == 1 {
+			p[k] = p[k].Add(p[k-1])
+		}
+
+		// Expand along the last column. The product of the subdiagonal entries from row i down is 1 until one of them
+		// isn't.
+		for i := k - 1; i >= 1 && h[i].GetBit(i-1) == 1; i-- {
+			if h[i-1].GetBit(k-1) == 1 {
+				p[k] = p[k].Add(p[i-1])
+			}
+		}
+	}
+
+	return p[n]
+}
+
+// hessenberg returns a matrix similar to e that's zero below the subdiagonal.
+func (e Matrix) hessenberg() Matrix {
+	n, _ := e.Size()
+	h := e.Dup()
+
+	// swapCols and addCol apply the inverse of each row operation on the right, so h stays similar to e.
+	swapCols := func(i, j int) {
+		for _, row := range h {
+			a, b := row.GetBit(i), row.GetBit(j)
+			row.SetBit(i, b == 1)
+			row.SetBit(j, a == 1)
+		}
+	}
+	addCol := func(dst, src int) {
+		for _, row := range h {
+			if row.GetBit(src) == 1 {
+				row.SetBit(dst, row.GetBit(dst) == 0)
+			}
+		}
+	}
+
+	for j := 0; j < n-2; j++ {
+		i := h.FindPivot(j+1, j)
+		if i == -1 {
+			continue
+		} else if i != j+1 {
+			h[i], h[j+1] = h[j+1], h[i]
+			swapCols(i, j+1)
+		}
+
+		for r := j + 2; r < n; r++ {
+			if h[r].GetBit(j) == 1 {
+				h[r] = h[r].Add(h[j+1])
+				addCol(j+1, r)
+			}
+		}
+	}
+
+	return h
+}
+
+// primaryPart describes the action of a matrix on the subspace where f(M) is nilpotent, for an irreducible factor f of
+// its characteristic polynomial.
+type primaryPart struct {
+	f Polynomial
+	n Matrix // f(M)
+
+	// ranks[k] is the rank of f(M)^k. The sequence stops once it's stable, so len(ranks)-1 is the multiplicity of f in
+	// the minimal polynomial.
+	ranks []int
+}
+
+// primaryParts returns the primary parts of a square matrix, in the order that CharPoly().Factor() returns its factors.
+func (e Matrix) primaryParts() (parts []primaryPart) {
+	n, _ := e.Size()
+
+	for _, factor := range e.CharPoly().Factor() {
+		part := primaryPart{f: factor.Poly, n: factor.Poly.Eval(e), ranks: []int{n}}
+
+		// The generalized eigenspace of f has dimension deg(f) * multiplicity, and f(M)^k's kernel grows until it's
+		// reached.
+		target := n - factor.Poly.Degree()*factor.Multiplicity
+		power := GenerateIdentity(n)
+
+		for part.ranks[len(part.ranks)-1] > target {
+			power = power.Compose(part.n)
+			part.ranks = append(part.ranks, power.Rank())
+		}
+
+		parts = append(parts, part)
+	}
+
+	return
+}
+
+// MinPoly returns the minimal polynomial of a square matrix: the monic polynomial p of least degree with p(M) = 0.
+func (e Matrix) MinPoly() Polynomial {
+	out := NewPolynomial(0)
+
+	for _, part := range e.primaryParts() {
+		for k := 1; k < len(part.ranks); k++ {
+			out = out.Mul(part.f)
+		}
+	}
+
+	return out
+}
+
+// Order returns the multiplicative order of an invertible matrix: the smallest k > 0 with M^k = I. It returns false if
+// the matrix isn't invertible.
+func (e Matrix) Order() (*big.Int, bool) {
+	out := big.NewInt(1)
+
+	for _, part := range e.primaryParts() {
+		if part.f.Equals(NewPolynomial(1)) {
+			return nil, false
+		}
+
+		// The order of x modulo f^k is the order of x modulo f times the smallest power of two that's at least k.
+		mult := len(part.ranks) - 1
+		ord := order(part.f)
+		for shift := 1; shift < mult; shift *= 2 {
+			ord.Lsh(ord, 1)
+		}
+
+		gcd := new(big.Int).GCD(nil, nil, out, ord)
+		out.Mul(out, ord).Quo(out, gcd)
+	}
+
+	return out, true
+}
+
+// IsSimilar returns true if there's an invertible P with e = P * f * P^-1.
+func (e Matrix) IsSimilar(f Matrix) bool {
+	n, m := e.Size()
+	p, q := f.Size()
+	if n != m || p != q || n != p {
+		return false
+	}
+
+	a, b := e.primaryParts(), f.primaryParts()
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, _ := range a {
+		if !a[i].f.Equals(b[i].f) || len(a[i].ranks) != len(b[i].ranks) {
+			return false
+		}
+
+		for k, _ := range a[i].ranks {
+			if a[i].ranks[k] != b[i].ranks[k] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Conjugator returns an invertible P with e = P * f * P^-1, if the two matrices are similar.
+func (e Matrix) Conjugator(f Matrix) (Matrix, bool) {
+	if !e.IsSimilar(f) {
+		return nil, false
+	}
+
+	// Both matrices are conjugate to the same canonical form, so P takes one canonical basis to the other.
+	s, _ := e.canonicalBasis()
+	t, _ := f.canonicalBasis()
+	tInv, _ := t.Invert()
+
+	return s.Compose(tInv), true
+}
+
+// canonicalBasis returns a matrix T such that T^-1 * M * T is the primary rational canonical form of M: a block
+// diagonal of companion matrices of the powers of each irreducible factor, sorted by factor and then by descending
+// power. Similar matrices have the same canonical form. It also returns the polynomial of each companion matrix on the
+// diagonal, in order.
+func (e Matrix) canonicalBasis() (t Matrix, blocks []Polynomial) {
+	n, _ := e.Size()
+	basis := []Row{}
+
+	for _, part := range e.primaryParts() {
+		d := part.f.Degree()
+
+		// kernels[k] is the kernel of f(M)^k.
+		kernels := []Subspace{NewSubspace(n)}
+		power := GenerateIdentity(n)
+		for k := 1; k < len(part.ranks); k++ {
+			power = power.Compose(part.n)
+			kernels = append(kernels, power.Kernel())
+		}
+
+		for k := len(part.ranks) - 1; k >= 1; k-- {
+			// A cyclic block of size f^k is generated by a vector in ker f(M)^k outside of ker f(M)^(k-1) + f(M) *
+			// ker f(M)^(k+1). The quotient is a vector space over GF(2)[x]/(f), so each new generator spans d
+			// dimensions of it.
+			span := kernels[k-1]
+			if k+1 < len(kernels) {
+				images := []Row{}
+				for _, v := range kernels[k+1].Basis() {
+					images = append(images, part.n.Mul(v))
+				}
+
+				span = span.Sum(NewSubspace(n, images...))
+			}
+
+			for _, v := range kernels[k].Basis() {
+				if span.Contains(v) {
+					continue
+				}
+
+				block := NewPolynomial(0)
+				for i := 0; i < k; i++ {
+					block = block.Mul(part.f)
+				}
+				blocks = append(blocks, block)
+
+				translates := []Row{}
+				for i, w := 0, v; i < d*k; i, w = i+1, e.Mul(w) {
+					basis = append(basis, w)
+					if i < d {
+						translates = append(translates, w)
+					}
+				}
+
+				span = span.Sum(NewSubspace(n, translates...))
+			}
+		}
+	}
+
+	return Matrix(basis).Transpose(), blocks
+}
@@ -0,0 +1,164 @@
+package matrix
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// power returns e^k.
+func power(e Matrix, k *big.Int) Matrix {
+	n, _ := e.Size()
+	out := GenerateIdentity(n)
+
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		out = out.Compose(out)
+		if k.Bit(i) == 1 {
+			out = out.Compose(e)
+		}
+	}
+
+	return out
+}
+
+func TestFactor(t *testing.T) {
+	// x^15 + 1 is the product of every irreducible polynomial whose degree divides 4, except x.
+	factors := NewPolynomial(15, 0).Factor()
+	cand := []Polynomial{
+		NewPolynomial(1, 0), NewPolynomial(2, 1, 0), NewPolynomial(4, 1, 0), NewPolynomial(4, 3, 0),
+		NewPolynomial(4, 3, 2, 1, 0),
+	}
+
+	if len(factors) != len(cand) {
+		t.Fatalf("x^15 + 1 had %v factors, not %v: %v", len(factors), len(cand), factors)
+	}
+
+	for i, f := range factors {
+		if !f.Poly.Equals(cand[i]) || f.Multiplicity != 1 {
+			t.Fatalf("Factor %v of x^15 + 1 was %v, not %v.", i, f.Poly, cand[i])
+		}
+	}
+
+	if !NewPolynomial(8, 4, 3, 1, 0).IsIrreducible() {
+		t.Fatal("Rijndael's polynomial isn't irreducible.")
+	}
+
+	// Factors with multiplicity should multiply back to the original.
+	p := NewPolynomial(2, 1, 0).Mul(NewPolynomial(2, 1, 0)).Mul(NewPolynomial(1, 0))
+	p = p.Mul(p).Mul(NewPolynomial(1)).Mul(NewPolynomial(7, 1, 0))
+
+	prod := NewPolynomial(0)
+	for _, f := range p.Factor() {
+		if !f.Poly.IsIrreducible() {
+			t.Fatalf("Factor %v isn't irreducible.", f.Poly)
+		}
+
+		for i := 0; i < f.Multiplicity; i++ {
+			prod = prod.Mul(f.Poly)
+		}
+	}
+
+	if !prod.Equals(p) {
+		t.Fatalf("Factors multiplied to %v, not %v.", prod, p)
+	}
+}
+
+func TestCharPoly(t *testing.T) {
+	m := GenerateTrueRandom(rand.Reader, 48)
+	char, min := m.CharPoly(), m.MinPoly()
+
+	if char.Degree() != 48 {
+		t.Fatalf("Characteristic polynomial has degree %v, not 48.", char.Degree())
+	} else if char.Eval(m).Rank() != 0 {
+		t.Fatal("Matrix isn't a root of its characteristic polynomial.")
+	} else if min.Eval(m).Rank() != 0 {
+		t.Fatal("Matrix isn't a root of its minimal polynomial.")
+	} else if !char.Mod(min).IsZero() {
+		t.Fatal("Minimal polynomial doesn't divide characteristic polynomial.")
+	}
+
+	// No proper divisor of the minimal polynomial kills the matrix.
+	for _, f := range min.Factor() {
+		if d, _ := min.DivMod(f.Poly); d.Eval(m).Rank() == 0 {
+			t.Fatalf("Minimal polynomial divided by %v still kills the matrix.", f.Poly)
+		}
+	}
+
+	if !GenerateIdentity(16).MinPoly().Equals(NewPolynomial(1, 0)) {
+		t.Fatal("Minimal polynomial of the identity isn't x + 1.")
+	}
+}
+
+func TestOrder(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		m := GenerateRandom(rand.Reader, 24)
+
+		ord, ok := m.Order()
+		if !ok {
+			t.Fatal("Order failed on invertible matrix.")
+		} else if !power(m, ord).Equals(GenerateIdentity(24)) {
+			t.Fatalf("M^%v isn't the identity.", ord)
+		}
+
+		for _, q := range primeFactors(ord) {
+			if power(m, new(big.Int).Quo(ord, q)).Equals(GenerateIdentity(24)) {
+				t.Fatalf("M^(%v/%v) is the identity, so %v isn't the order.", ord, q, ord)
+			}
+		}
+	}
+
+	// A 2-by-2 Jordan block has order 2.
+	m := GenerateIdentity(8)
+	m[0].SetBit(1, true)
+	if ord, _ := m.Order(); ord.Int64() != 2 {
+		t.Fatalf("Unipotent matrix had order %v, not 2.", ord)
+	}
+
+	if _, ok := GenerateEmpty(8, 8).Order(); ok {
+		t.Fatal("Order succeeded on singular matrix.")
+	}
+}
+
+func TestConjugator(t *testing.T) {
+	// A unipotent matrix with several Jordan blocks of each size, next to a generic part.
+	b := GenerateIdentity(32)
+	for _, pos := range [][2]int{{0, 1}, {1, 2}, {4, 5}, {6, 7}, {8, 9}, {9, 10}} {
+		b[pos[0]].SetBit(pos[1], true)
+	}
+	generic := GenerateTrueRandom(rand.Reader, 16)
+	for i := 0; i < 16; i++ {
+		b[16+i] = NewRow(32)
+		for j := 0; j < 16; j++ {
+			b[16+i].SetBit(16+j, generic[i].GetBit(j) == 1)
+		}
+	}
+
+	for _, f := range []Matrix{b, GenerateTrueRandom(rand.Reader, 64)} {
+		n, _ := f.Size()
+		p, pInv := GenerateRandomWithInverse(rand.Reader, n)
+		e := p.Compose(f).Compose(pInv)
+
+		if !e.IsSimilar(f) {
+			t.Fatal("Conjugate matrices weren't similar.")
+		}
+
+		q, ok := e.Conjugator(f)
+		if !ok {
+			t.Fatal("Conjugator failed on similar matrices.")
+		} else if _, ok := q.Invert(); !ok {
+			t.Fatal("Conjugator isn't invertible.")
+		} else if !e.Compose(q).Equals(q.Compose(f)) {
+			t.Fatal("Conjugator doesn't conjugate one matrix to the other.")
+		}
+	}
+
+	// Same characteristic polynomial, different Jordan blocks.
+	c := GenerateIdentity(32)
+	c[0].SetBit(1, true)
+
+	if c.IsSimilar(GenerateIdentity(32)) {
+		t.Fatal("Identity was similar to a non-identity matrix.")
+	} else if _, ok := c.Conjugator(b); ok {
+		t.Fatal("Conjugator succeeded on matrices that aren't similar.")
+	}
+}
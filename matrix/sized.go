@@ -0,0 +1,285 @@
+package matrix
+
+import (
+	"io"
+)
+
+// SizedRow is a row with an explicit length in bits. A Row is stored in whole bytes, so its size is always a multiple of
+// 8; a SizedRow of length n keeps the bits of its last byte past n zero, so they never leak into sizes, sums or
+// products. This is what nibble-sized or 6-bit components, or 63-bit structures, need.
+type SizedRow struct {
+	Row
+	n int
+}
+
+// NewSizedRow returns an empty n-component sized row.
+func NewSizedRow(n int) SizedRow {
+	return SizedRow{NewRow(n), n}
+}
+
+// ToSizedRow returns the first n components of row as a sized row. The bits of row past n are cleared.
+func ToSizedRow(row Row, n int) SizedRow {
+	if row.Size() < n {
+		panic("Can't size row beyond its length!")
+	}
+
+	out := make(Row, rowsToColumns(n))
+	copy(out, row)
+	mask(out, n)
+
+	return SizedRow{out, n}
+}
+
+// GenerateRandomSizedRow generates a random n-component sized row.
+func GenerateRandomSizedRow(reader io.Reader, n int) SizedRow {
+	out := GenerateRandomRow(reader, n)
+	mask(out, n)
+
+	return SizedRow{out, n}
+}
+
+// mask clears the bits of row past the nth.
+func mask(row Row, n int) {
+	if n%8 != 0 {
+		row[len(row)-1] &= byte(1)<<uint(n%8) - 1
+	}
+}
+
+// Size returns the dimension of the vector.
+func (e SizedRow) Size() int {
+	return e.n
+}
+
+// Add adds (XORs) two vectors.
+func (e SizedRow) Add(f SizedRow) SizedRow {
+	if e.n != f.n {
+		panic("Can't add rows that are different sizes!")
+	}
+
+	return SizedRow{e.Row.Add(f.Row), e.n}
+}
+
+// Equals returns true if two rows are equal and false otherwise.
+func (e SizedRow) Equals(f SizedRow) bool {
+	return e.n == f.n && e.Row.Equals(f.Row)
+}
+
+// Dup returns a duplicate of this row.
+func (e SizedRow) Dup() SizedRow {
+	return SizedRow{e.Row.Dup(), e.n}
+}
+
+// String converts the row into space-and-dot notation.
+func (e SizedRow) String() string {
+	out := []rune{'|'}
+
+	for i := 0; i < e.n; i++ {
+		if e.GetBit(i) == 0 {
+			out = append(out, ' ')
+		} else {
+			out = append(out, '•')
+		}
+	}
+
+	return string(append(out, '|', '\n'))
+}
+
+// OctaveString converts the row into a string that can be imported into Octave.
+func (e SizedRow) OctaveString() string {
+	out := []rune{}
+
+	for i := 0; i < e.n; i++ {
+		out = append(out, rune('0'+e.GetBit(i)), ' ')
+	}
+
+	return string(append(out, '\n'))
+}
+
+// SizedMatrix is a matrix with an explicit number of columns, which needn't be a multiple of 8. Each row is stored as a
+// Row with the bits past the last column kept zero.
+//
+// The Matrix field isn't embedded, because most of Matrix's methods--Solve, Image, Kernel, PLU and so on--work on the
+// byte-padded width instead of the number of columns. Use it directly only where the padding doesn't matter.
+type SizedMatrix struct {
+	Matrix Matrix
+	cols   int
+}
+
+// NewSizedMatrix returns the first cols columns of e as a sized matrix. The bits of e past cols are cleared.
+func NewSizedMatrix(e Matrix, cols int) SizedMatrix {
+	out := make(Matrix, len(e))
+	for i, row := range e {
+		out[i] = ToSizedRow(row, cols).Row
+	}
+
+	return SizedMatrix{out, cols}
+}
+
+// GenerateEmptySized generates the n-by-m sized matrix with all entries set to 0.
+func GenerateEmptySized(n, m int) SizedMatrix {
+	return SizedMatrix{GenerateEmpty(n, m), m}
+}
+
+// GenerateIdentitySized generates the n-by-n sized identity matrix.
+func GenerateIdentitySized(n int) SizedMatrix {
+	return SizedMatrix{GenerateIdentity(n), n}
+}
+
+// GenerateRandomSized generates a random invertible n-by-n sized matrix and its inverse using the random source reader
+// (for example, crypto/rand.Reader).
+func GenerateRandomSized(reader io.Reader, n int) (SizedMatrix, SizedMatrix) {
+	m, mInv := GenerateRandomWithInverse(reader, n)
+	return SizedMatrix{m, n}, SizedMatrix{mInv, n}
+}
+
+// Size returns the dimensions of the matrix in (Rows, Columns) order.
+func (e SizedMatrix) Size() (int, int) {
+	return len(e.Matrix), e.cols
+}
+
+// Row returns the ith row of the matrix. It shares storage with the matrix.
+func (e SizedMatrix) Row(i int) SizedRow {
+	return SizedRow{e.Matrix[i], e.cols}
+}
+
+// Mul right-multiplies a matrix by a row.
+func (e SizedMatrix) Mul(f SizedRow) SizedRow {
+	if e.cols != f.n {
+		panic("Can't multiply by row that is wrong size!")
+	}
+
+	out := NewSizedRow(len(e.Matrix))
+	for i, row := range e.Matrix {
+		if row.DotProduct(f.Row) {
+			out.SetBit(i, true)
+		}
+	}
+
+	return out
+}
+
+// Add adds two sized matrices.
+func (e SizedMatrix) Add(f SizedMatrix) SizedMatrix {
+	if n, m := f.Size(); len(e.Matrix) != n || e.cols != m {
+		panic("Can't add matrices of different sizes!")
+	}
+
+	return SizedMatrix{e.Matrix.Add(f.Matrix), e.cols}
+}
+
+// Compose returns the result of composing e with f.
+func (e SizedMatrix) Compose(f SizedMatrix) SizedMatrix {
+	if e.cols != len(f.Matrix) {
+		panic("Can't multiply matrices of wrong size!")
+	} else if len(e.Matrix) == 0 || e.cols == 0 {
+		return GenerateEmptySized(len(e.Matrix), f.cols)
+	}
+
+	// Pad f with zero rows for e's padding columns, which are zero anyway, so that the byte-granular sizes line up.
+	padded := append(f.Matrix[:len(f.Matrix):len(f.Matrix)], GenerateEmpty(8*rowsToColumns(e.cols)-e.cols, f.cols)...)
+
+	return SizedMatrix{e.Matrix.Compose(padded), f.cols}
+}
+
+// Transpose returns the transpose of a matrix.
+func (e SizedMatrix) Transpose() SizedMatrix {
+	n, m := e.Size()
+	out := GenerateEmptySized(m, n)
+
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			out.Matrix[i].SetBit(j, e.Matrix[j].GetBit(i) == 1)
+		}
+	}
+
+	return out
+}
+
+// Invert computes the multiplicative inverse of a matrix, if it exists.
+func (e SizedMatrix) Invert() (SizedMatrix, bool) {
+	if n, m := e.Size(); n != m {
+		return SizedMatrix{}, false
+	}
+
+	// The padding columns are always free, so only a free variable before them makes the matrix singular.
+	inv, _, frees := e.Matrix.gaussJordan()
+	if len(frees) > 0 && frees[0] < e.cols {
+		return SizedMatrix{}, false
+	}
+
+	return SizedMatrix{inv, e.cols}, true
+}
+
+// NullSpace returns a basis for the matrix's nullspace.
+func (e SizedMatrix) NullSpace() (basis []SizedRow) {
+	if len(e.Matrix) == 0 {
+		for col := 0; col < e.cols; col++ {
+			basis = append(basis, NewSizedRow(e.cols))
+			basis[col].SetBit(col, true)
+		}
+
+		return
+	}
+
+	_, f, frees := e.Matrix.gaussJordan()
+
+	for _, free := range frees {
+		if free >= e.cols {
+			break
+		}
+
+		input := NewSizedRow(e.cols)
+		input.SetBit(free, true)
+
+		for _, row := range f {
+			if row.GetBit(free) == 1 {
+				input.SetBit(row.Height(), true)
+			}
+		}
+
+		basis = append(basis, input)
+	}
+
+	return
+}
+
+// Equals returns true if two matrices are equal and false otherwise.
+func (e SizedMatrix) Equals(f SizedMatrix) bool {
+	return e.cols == f.cols && len(e.Matrix) == len(f.Matrix) && e.Matrix.Equals(f.Matrix)
+}
+
+// Dup returns a duplicate of this matrix.
+func (e SizedMatrix) Dup() SizedMatrix {
+	return SizedMatrix{e.Matrix.Dup(), e.cols}
+}
+
+// String converts the matrix to space-and-dot notation.
+func (e SizedMatrix) String() string {
+	out := []rune{}
+
+	addBar := func() {
+		for i := -2; i < e.cols; i++ {
+			out = append(out, '-')
+		}
+		out = append(out, '\n')
+	}
+
+	addBar()
+	for i, _ := range e.Matrix {
+		out = append(out, []rune(e.Row(i).String())...)
+	}
+	addBar()
+
+	return string(out)
+}
+
+// OctaveString converts the matrix into a string that can be imported into Octave.
+func (e SizedMatrix) OctaveString() string {
+	out := []rune{}
+
+	for i, _ := range e.Matrix {
+		out = append(out, []rune(e.Row(i).OctaveString())...)
+	}
+
+	return string(out)
+}
@@ -0,0 +1,107 @@
+package matrix
+
+import (
+	"testing"
+
+	"crypto/rand"
+)
+
+func TestSizedRow(t *testing.T) {
+	x := ToSizedRow(Row{0xff}, 6)
+
+	if x.Size() != 6 || x.Weight() != 6 || x.Row[0] != 0x3f {
+		t.Fatalf("ToSizedRow didn't mask the bits past the end of the row: %v", x)
+	} else if x.String() != "|••••••|\n" {
+		t.Fatalf("String is wrong: %q", x.String())
+	}
+
+	y := GenerateRandomSizedRow(rand.Reader, 63)
+	if y.Row[7]&0x80 != 0 {
+		t.Fatalf("GenerateRandomSizedRow didn't mask the bits past the end of the row.")
+	} else if !y.Add(y).Equals(NewSizedRow(63)) {
+		t.Fatalf("Row plus itself isn't zero.")
+	}
+}
+
+func TestSizedMatrixInvert(t *testing.T) {
+	for _, n := range []int{1, 4, 6, 8, 13, 63, 64} {
+		m, mInv := GenerateRandomSized(rand.Reader, n)
+		inv, ok := m.Invert()
+
+		if !ok {
+			t.Fatalf("Failed to invert random %v-by-%v matrix.", n, n)
+		} else if !inv.Equals(mInv) {
+			t.Fatalf("GenerateRandomSized returned the wrong inverse of a %v-by-%v matrix.", n, n)
+		} else if !m.Compose(mInv).Equals(GenerateIdentitySized(n)) || !mInv.Compose(m).Equals(GenerateIdentitySized(n)) {
+			t.Fatalf("Inverse of %v-by-%v matrix is wrong.", n, n)
+		}
+
+		x := GenerateRandomSizedRow(rand.Reader, n)
+		if !mInv.Mul(m.Mul(x)).Equals(x) {
+			t.Fatalf("Inverse of %v-by-%v matrix doesn't undo it on a row.", n, n)
+		} else if !m.Transpose().Transpose().Equals(m) {
+			t.Fatalf("Transpose of %v-by-%v matrix isn't an involution.", n, n)
+		}
+
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if m.Transpose().Matrix[i].GetBit(j) != m.Matrix[j].GetBit(i) {
+					t.Fatalf("Transpose of %v-by-%v matrix is wrong.", n, n)
+				}
+			}
+		}
+	}
+
+	// A nibble matrix whose padding bits would be free variables if treated as real.
+	nibble := NewSizedMatrix(Matrix{Row{0x01}, Row{0x03}, Row{0x07}, Row{0x0f}}, 4)
+	if _, ok := nibble.Invert(); !ok {
+		t.Fatalf("Failed to invert invertible 4-by-4 matrix.")
+	}
+
+	singular := NewSizedMatrix(Matrix{Row{0x01}, Row{0x03}, Row{0x02}, Row{0x0f}}, 4)
+	if _, ok := singular.Invert(); ok {
+		t.Fatalf("Inverted singular 4-by-4 matrix.")
+	}
+}
+
+func TestSizedMatrixNullSpace(t *testing.T) {
+	// 3-by-6 matrix with rank 3, so its nullspace has dimension 3.
+	m := NewSizedMatrix(Matrix{Row{0x07}, Row{0x38}, Row{0x09}}, 6)
+	basis := m.NullSpace()
+
+	if len(basis) != 3 {
+		t.Fatalf("NullSpace returned %v vectors, not 3.", len(basis))
+	}
+
+	for _, x := range basis {
+		if x.Size() != 6 {
+			t.Fatalf("NullSpace returned vector of size %v, not 6.", x.Size())
+		} else if !m.Mul(x).Equals(NewSizedRow(3)) {
+			t.Fatalf("NullSpace returned vector not in the nullspace: %v", x)
+		}
+	}
+
+	if len(GenerateEmptySized(0, 5).NullSpace()) != 5 {
+		t.Fatalf("NullSpace of matrix with no rows isn't everything.")
+	}
+}
+
+func TestSizedMatrixCompose(t *testing.T) {
+	a, b := GenerateEmptySized(5, 7), GenerateEmptySized(7, 3)
+	for i := 0; i < 5; i++ {
+		a.Matrix[i] = GenerateRandomSizedRow(rand.Reader, 7).Row
+	}
+	for i := 0; i < 7; i++ {
+		b.Matrix[i] = GenerateRandomSizedRow(rand.Reader, 3).Row
+	}
+
+	ab := a.Compose(b)
+	if n, m := ab.Size(); n != 5 || m != 3 {
+		t.Fatalf("Composition is %v-by-%v, not 5-by-3.", n, m)
+	}
+
+	x := GenerateRandomSizedRow(rand.Reader, 3)
+	if !ab.Mul(x).Equals(a.Mul(b.Mul(x))) {
+		t.Fatalf("Composition is wrong.")
+	}
+}
@@ -0,0 +1,123 @@
+package matrix
+
+import (
+	"io"
+)
+
+// AffineSubspace is a translate of a linear subspace: every Offset + x where x is in Linear. It's the shape of the
+// solution set of a consistent linear system.
+type AffineSubspace struct {
+	Offset Row
+	Linear Subspace
+}
+
+// Dim returns the dimension of the affine subspace.
+func (as AffineSubspace) Dim() int {
+	return as.Linear.Dim()
+}
+
+// Contains returns whether or not x is in the affine subspace.
+func (as AffineSubspace) Contains(x Row) bool {
+	return as.Linear.Contains(x.Add(as.Offset))
+}
+
+// Random returns a uniformly random element of the affine subspace using the random source reader (for example,
+// crypto/rand.Reader).
+func (as AffineSubspace) Random(reader io.Reader) Row {
+	return as.Offset.Add(as.Linear.Random(reader))
+}
+
+// Solve returns the set of all x such that e.Mul(x) = b, or ErrNoSolution if there are none. It returns
+// ErrDimensionMismatch if b is the wrong size.
+func (e Matrix) Solve(b Row) (AffineSubspace, error) {
+	sols, errs := e.SolveMany([]Row{b})
+	return sols[0], errs[0]
+}
+
+// SolveMany solves e.Mul(x) = b for every b in bs, sharing one elimination between all of them. The ith solution set
+// corresponds to bs[i]; if that system is inconsistent, the ith error is ErrNoSolution and the solution set is empty. If
+// bs[i] is the wrong size, the ith error is ErrDimensionMismatch instead. Like e.Mul's outputs, each b has as many bytes
+// as it takes to hold a bit per row of e.
+func (e Matrix) SolveMany(bs []Row) (sols []AffineSubspace, errs []error) {
+	out, in := e.Size()
+
+	// Reduce e with every right-hand side appended as an extra column.
+	w := newWords(out, in+len(bs))
+	for i, row := range e {
+		packRow(w.row(i), row, 0)
+	}
+
+	sols, errs = make([]AffineSubspace, len(bs)), make([]error, len(bs))
+
+	for j, b := range bs {
+		if len(b) != rowsToColumns(out) {
+			errs[j] = ErrDimensionMismatch
+			continue
+		}
+
+		for i := 0; i < out; i++ {
+			if b.GetBit(i) == 1 {
+				w.flip(i, in+j)
+			}
+		}
+	}
+
+	pivots := w.reduce(in)
+	kernel := w.kernel(in, pivots)
+
+	for j := range bs {
+		if errs[j] != nil {
+			continue
+		}
+
+		// e.Mul pads its outputs with zeros, so b is out of the image if any of its padding is set.
+		consistent := true
+		for i := out; i < bs[j].Size() && consistent; i++ {
+			consistent = bs[j].GetBit(i) == 0
+		}
+		for i := len(pivots); i < out && consistent; i++ {
+			consistent = w.bit(i, in+j) == 0
+		}
+
+		if !consistent {
+			errs[j] = ErrNoSolution
+			continue
+		}
+
+		// Setting every free variable to zero gives a particular solution.
+		offset := NewRow(in)
+		for i, p := range pivots {
+			offset.SetBit(p, w.bit(i, in+j) == 1)
+		}
+
+		sols[j] = AffineSubspace{Offset: offset, Linear: kernel}
+	}
+
+	return
+}
+
+// kernel returns the null space of the first n columns of w, given that they're in reduced row echelon form with the
+// given pivots.
+func (w *words) kernel(n int, pivots []int) Subspace {
+	vectors := []Row{}
+
+	for col, k := 0, 0; col < n; col++ {
+		if k < len(pivots) && pivots[k] == col {
+			k++
+			continue
+		}
+
+		v := NewRow(n)
+		v.SetBit(col, true)
+
+		for i, p := range pivots {
+			if w.bit(i, col) == 1 {
+				v.SetBit(p, true)
+			}
+		}
+
+		vectors = append(vectors, v)
+	}
+
+	return NewSubspace(n, vectors...)
+}
@@ -0,0 +1,84 @@
+package matrix
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	m := GenerateTrueRandom(rand.Reader, 64)
+	m[3] = m[10].Add(m[20])
+
+	x := GenerateRandomRow(rand.Reader, 64)
+	sols, err := m.Solve(m.Mul(x))
+	if err != nil {
+		t.Fatalf("Solve returned error on consistent system: %v", err)
+	}
+
+	if !sols.Contains(x) {
+		t.Fatal("Solution set doesn't contain the original input.")
+	} else if !sols.Linear.Equals(m.Kernel()) {
+		t.Fatal("Solution set isn't a translate of the kernel.")
+	}
+
+	for i := 0; i < 100; i++ {
+		if !m.Mul(sols.Random(rand.Reader)).Equals(m.Mul(x)) {
+			t.Fatal("Solution set contains a non-solution.")
+		}
+	}
+
+	// Bit 3 of every element of the image is the sum of bits 10 and 20, so this b isn't in it.
+	b := m.Mul(x)
+	b.SetBit(3, b.GetBit(10)^b.GetBit(20) == 0)
+
+	if _, err := m.Solve(b); err != ErrNoSolution {
+		t.Fatalf("Solve didn't report inconsistent system: %v", err)
+	}
+}
+
+func TestSolveUnevenRows(t *testing.T) {
+	m := GenerateEmpty(12, 16)
+	for i, _ := range m {
+		m[i] = GenerateRandomRow(rand.Reader, 16)
+	}
+
+	x := GenerateRandomRow(rand.Reader, 16)
+	sols, err := m.Solve(m.Mul(x))
+	if err != nil {
+		t.Fatalf("Solve returned error on 12-row system: %v", err)
+	} else if !sols.Contains(x) {
+		t.Fatal("Solution set doesn't contain the original input.")
+	}
+
+	// Bit 12 is padding, which no output of m has set.
+	b := m.Mul(x)
+	b.SetBit(12, true)
+
+	if _, err := m.Solve(b); err != ErrNoSolution {
+		t.Fatalf("Solve didn't reject b with padding set: %v", err)
+	} else if _, err := m.Solve(NewRow(8)); err != ErrDimensionMismatch {
+		t.Fatalf("Solve didn't report dimension mismatch: %v", err)
+	}
+}
+
+func TestSolveMany(t *testing.T) {
+	m := GenerateRandom(rand.Reader, 128)
+	mInv, _ := m.Invert()
+
+	bs := []Row{}
+	for i := 0; i < 20; i++ {
+		bs = append(bs, GenerateRandomRow(rand.Reader, 128))
+	}
+
+	sols, errs := m.SolveMany(bs)
+
+	for i, b := range bs {
+		if errs[i] != nil {
+			t.Fatalf("SolveMany returned error on invertible system: %v", errs[i])
+		} else if sols[i].Dim() != 0 {
+			t.Fatal("Invertible system had more than one solution.")
+		} else if !sols[i].Offset.Equals(mInv.Mul(b)) {
+			t.Fatal("SolveMany returned the wrong solution.")
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package matrix
+
+import (
+	"io"
+)
+
+// Subspace is a linear subspace of GF(2)^n. It's stored as a basis in reduced row echelon form, which is unique to the
+// subspace, so two Subspaces are equal exactly when their bases are.
+type Subspace struct {
+	n     int    // The dimension of the ambient space.
+	basis Matrix // The canonical basis, sorted by height.
+}
+
+// NewSubspace returns the subspace of GF(2)^n spanned by the given vectors.
+func NewSubspace(n int, vectors ...Row) Subspace {
+	w := newWords(len(vectors), n)
+
+	for i, v := range vectors {
+		if v.Size() != n {
+			panic("Can't span subspace with row that is wrong size!")
+		}
+
+		packRow(w.row(i), v, 0)
+	}
+
+	pivots := w.reduce(n)
+
+	return Subspace{
+		n:     n,
+		basis: w.unpack(0, n)[:len(pivots)],
+	}
+}
+
+// Image returns the image (column space) of the matrix. Outputs of Mul are padded to a whole number of bytes, so the
+// image lives in GF(2)^(8*ceil(n/8)) for a matrix with n rows.
+func (e Matrix) Image() Subspace {
+	n, _ := e.Size()
+	return NewSubspace(8*rowsToColumns(n), e.Transpose()...)
+}
+
+// Kernel returns the kernel (null space) of the matrix.
+func (e Matrix) Kernel() Subspace {
+	_, m := e.Size()
+	return NewSubspace(m, e.NullSpace()...)
+}
+
+// Rank returns the rank of the matrix--the dimension of its image.
+func (e Matrix) Rank() int {
+	_, m := e.Size()
+	return len(e.pack().reduce(m))
+}
+
+// Size returns the dimension of the space the subspace lives in.
+func (s Subspace) Size() int {
+	return s.n
+}
+
+// Dim returns the dimension of the subspace.
+func (s Subspace) Dim() int {
+	return len(s.basis)
+}
+
+// Basis returns the canonical basis of the subspace.
+func (s Subspace) Basis() Matrix {
+	return s.basis.Dup()
+}
+
+// Project returns the canonical representative of x + S, the coset of x in the quotient space. The representative is
+// zero in the position of the first non-zero entry of every basis vector, so two vectors project to the same row
+// exactly when their difference is in the subspace.
+func (s Subspace) Project(x Row) Row {
+	if x.Size() != s.n {
+		panic("Can't project row that is wrong size!")
+	}
+
+	out := x.Dup()
+	for _, row := range s.basis {
+		if out.GetBit(row.Height()) == 1 {
+			out = out.Add(row)
+		}
+	}
+
+	return out
+}
+
+// Contains returns whether or not x is in the subspace.
+func (s Subspace) Contains(x Row) bool {
+	return s.Project(x).IsZero()
+}
+
+// Sum returns the smallest subspace containing both s and t.
+func (s Subspace) Sum(t Subspace) Subspace {
+	if s.n != t.n {
+		panic("Can't add subspaces of different spaces!")
+	}
+
+	vectors := append(s.basis.Dup(), t.basis...)
+	return NewSubspace(s.n, vectors...)
+}
+
+// Intersect returns the intersection of s and t.
+func (s Subspace) Intersect(t Subspace) Subspace {
+	if s.n != t.n {
+		panic("Can't intersect subspaces of different spaces!")
+	}
+
+	// Zassenhaus' algorithm: reduce the rows (s_i | s_i) and (t_j | 0). The rows that end up zero in the left half span
+	// the intersection in the right half.
+	n := s.n
+	w := newWords(s.Dim()+t.Dim(), 2*n)
+
+	for i, row := range s.basis {
+		packRow(w.row(i), row, 0)
+		packRow(w.row(i), row, n)
+	}
+
+	for j, row := range t.basis {
+		packRow(w.row(s.Dim()+j), row, 0)
+	}
+
+	w.reduce(2 * n)
+
+	vectors := []Row{}
+	for i := 0; i < w.rows; i++ {
+		left, right := NewRow(n), NewRow(n)
+		unpackRow(left, w.row(i), 0, n)
+		unpackRow(right, w.row(i), n, n)
+
+		if left.IsZero() && !right.IsZero() {
+			vectors = append(vectors, right)
+		}
+	}
+
+	return NewSubspace(n, vectors...)
+}
+
+// Complement returns a subspace C such that S + C is the whole space and S and C only intersect at zero. C is spanned
+// by the unit vectors that aren't the position of the first non-zero entry of a basis vector, so it contains exactly
+// the representatives returned by Project.
+func (s Subspace) Complement() Subspace {
+	pivot := make([]bool, s.n)
+	for _, row := range s.basis {
+		pivot[row.Height()] = true
+	}
+
+	vectors := []Row{}
+	for i := 0; i < s.n; i++ {
+		if !pivot[i] {
+			v := NewRow(s.n)
+			v.SetBit(i, true)
+
+			vectors = append(vectors, v)
+		}
+	}
+
+	return NewSubspace(s.n, vectors...)
+}
+
+// Equals returns true if two subspaces are equal and false otherwise.
+func (s Subspace) Equals(t Subspace) bool {
+	if s.n != t.n || s.Dim() != t.Dim() {
+		return false
+	}
+
+	return s.basis.Equals(t.basis)
+}
+
+// Random returns a uniformly random element of the subspace using the random source reader (for example,
+// crypto/rand.Reader).
+func (s Subspace) Random(reader io.Reader) Row {
+	out := NewRow(s.n)
+	if s.Dim() == 0 {
+		return out
+	}
+
+	coeffs := GenerateRandomRow(reader, s.Dim())
+	for i, row := range s.basis {
+		if coeffs.GetBit(i) == 1 {
+			out = out.Add(row)
+		}
+	}
+
+	return out
+}
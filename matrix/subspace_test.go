@@ -0,0 +1,146 @@
+package matrix
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func randomSubspace(n, k int) Subspace {
+	vectors := []Row{}
+	for i := 0; i < k; i++ {
+		vectors = append(vectors, GenerateRandomRow(rand.Reader, n))
+	}
+
+	return NewSubspace(n, vectors...)
+}
+
+func TestSubspaceCanonical(t *testing.T) {
+	S := randomSubspace(64, 20)
+
+	// Any set of random vectors in S that spans it should give the same basis.
+	vectors := []Row{}
+	for NewSubspace(64, vectors...).Dim() < S.Dim() {
+		vectors = append(vectors, S.Random(rand.Reader))
+	}
+
+	if !NewSubspace(64, vectors...).Equals(S) {
+		t.Fatal("Two spanning sets of the same subspace gave different bases.")
+	}
+
+	for i := 0; i < 100; i++ {
+		if !S.Contains(S.Random(rand.Reader)) {
+			t.Fatal("Subspace doesn't contain its own random element.")
+		}
+	}
+}
+
+func TestRankImageKernel(t *testing.T) {
+	m := GenerateTrueRandom(rand.Reader, 64)
+	m[3] = m[10].Add(m[20])
+	m[4] = m[10].Add(m[30])
+
+	im, ker := m.Image(), m.Kernel()
+
+	if m.Rank() != im.Dim() {
+		t.Fatalf("Rank is %v but image has dimension %v.", m.Rank(), im.Dim())
+	} else if im.Dim()+ker.Dim() != 64 {
+		t.Fatalf("Rank-nullity failed: %v + %v != 64.", im.Dim(), ker.Dim())
+	}
+
+	for i := 0; i < 100; i++ {
+		x := GenerateRandomRow(rand.Reader, 64)
+
+		if !im.Contains(m.Mul(x)) {
+			t.Fatal("Image doesn't contain output of matrix.")
+		} else if !m.Mul(ker.Random(rand.Reader)).IsZero() {
+			t.Fatal("Matrix doesn't send element of kernel to zero.")
+		}
+	}
+
+	if GenerateRandom(rand.Reader, 64).Rank() != 64 {
+		t.Fatal("Invertible matrix doesn't have full rank.")
+	}
+}
+
+func TestImageUnevenRows(t *testing.T) {
+	m := GenerateEmpty(12, 16)
+	for i, _ := range m {
+		m[i] = GenerateRandomRow(rand.Reader, 16)
+	}
+
+	im := m.Image()
+	if im.Size() != 16 || im.Dim() != m.Rank() {
+		t.Fatalf("Image of 12x16 matrix is a %v-dimensional subspace of GF(2)^%v.", im.Dim(), im.Size())
+	}
+
+	for i := 0; i < 100; i++ {
+		if !im.Contains(m.Mul(GenerateRandomRow(rand.Reader, 16))) {
+			t.Fatal("Image doesn't contain output of matrix.")
+		}
+	}
+}
+
+func TestSubspaceSumIntersect(t *testing.T) {
+	// S and T share C, so their intersection is at least C and has dimension dim S + dim T - dim(S + T).
+	C := randomSubspace(64, 10)
+	S := C.Sum(randomSubspace(64, 20))
+	T := C.Sum(randomSubspace(64, 20))
+
+	sum, meet := S.Sum(T), S.Intersect(T)
+
+	if S.Dim()+T.Dim() != sum.Dim()+meet.Dim() {
+		t.Fatalf("dim S + dim T != dim(S + T) + dim(S & T): %v + %v != %v + %v", S.Dim(), T.Dim(), sum.Dim(), meet.Dim())
+	} else if !C.Intersect(meet).Equals(C) {
+		t.Fatal("Intersection doesn't contain common subspace.")
+	}
+
+	for i := 0; i < 100; i++ {
+		x := meet.Random(rand.Reader)
+		if !S.Contains(x) || !T.Contains(x) {
+			t.Fatal("Intersection contains an element outside of S or T.")
+		}
+
+		y := S.Random(rand.Reader).Add(T.Random(rand.Reader))
+		if !sum.Contains(y) {
+			t.Fatal("Sum doesn't contain the sum of elements of S and T.")
+		}
+	}
+}
+
+func TestSubspaceDifferentSpaces(t *testing.T) {
+	S, T := randomSubspace(64, 10), randomSubspace(128, 10)
+
+	for name, op := range map[string]func(){"Sum": func() { S.Sum(T) }, "Intersect": func() { S.Intersect(T) }} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%v didn't panic on subspaces of different spaces.", name)
+				}
+			}()
+
+			op()
+		}()
+	}
+}
+
+func TestSubspaceComplementProject(t *testing.T) {
+	S := randomSubspace(64, 30)
+	C := S.Complement()
+
+	if S.Dim()+C.Dim() != 64 || S.Intersect(C).Dim() != 0 {
+		t.Fatal("Complement isn't a complement.")
+	}
+
+	for i := 0; i < 100; i++ {
+		x := GenerateRandomRow(rand.Reader, 64)
+		p := S.Project(x)
+
+		if !C.Contains(p) {
+			t.Fatal("Projection isn't in the complement.")
+		} else if !S.Contains(x.Add(p)) {
+			t.Fatal("Projection isn't in the same coset.")
+		} else if !S.Project(x.Add(S.Random(rand.Reader))).Equals(p) {
+			t.Fatal("Projection isn't constant on cosets.")
+		}
+	}
+}
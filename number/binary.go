@@ -0,0 +1,405 @@
+package number
+
+import (
+	"math/big"
+	"math/bits"
+	"sort"
+
+	"github.com/OpenWhiteBox/primitives/internal/poly"
+)
+
+// Field is the binary field GF(2^n) defined by an irreducible polynomial of degree n over GF(2), for any 1 <= n <= 64.
+// Its elements are uint64s whose bit i is the coefficient of x^i, so only the low n bits are used.
+//
+// FieldElem[M] is the other way to describe a binary field: its field is fixed at compile time and part of its type,
+// which is what lets gfmatrix build matrices over it, but it only goes up to degree 8. Field is chosen at runtime and
+// goes up to degree 64, for finding isomorphisms between fields and checking irreducibility. NewFieldOf gives the
+// Field with the same arithmetic as a FieldElem[M].
+type Field struct {
+	degree int
+	poly   uint64 // The modulus without its x^n term, which doesn't fit when n = 64.
+
+	orderFactors []uint64 // The distinct prime factors of 2^n - 1, the order of the multiplicative group.
+}
+
+// NewField returns the field GF(2^degree) defined by the polynomial poly, with bit i set if x^i has a non-zero
+// coefficient. The x^degree term is implied, so it may be left out--it has to be for degree 64. It panics if poly
+// isn't irreducible.
+func NewField(degree int, poly uint64) Field {
+	poly = normalizePoly(degree, poly)
+	if !isIrreducible(degree, poly) {
+		panic("Can't build field from reducible polynomial!")
+	}
+
+	return Field{degree, poly, factorOrder(^uint64(0) >> uint(64-degree))}
+}
+
+// NewFieldOf returns the field defined by the modulus M, which has the same arithmetic as FieldElem[M].
+func NewFieldOf[M Modulus]() Field {
+	var m M
+	return NewField(bits.Len16(m.Poly())-1, uint64(m.Poly()))
+}
+
+// IsIrreducible returns true if poly is irreducible over GF(2), where poly is given the same way as in NewField.
+func IsIrreducible(degree int, poly uint64) bool {
+	return isIrreducible(degree, normalizePoly(degree, poly))
+}
+
+// normalizePoly checks that poly has the given degree and strips its x^degree term.
+func normalizePoly(degree int, poly uint64) uint64 {
+	if degree < 1 || degree > 64 {
+		panic("Can't build field of degree less than 1 or more than 64!")
+	} else if degree < 64 {
+		if poly>>uint(degree) > 1 {
+			panic("Can't build field from polynomial of the wrong degree!")
+		}
+
+		poly &^= 1 << uint(degree)
+	}
+
+	return poly
+}
+
+// isIrreducible implements Rabin's test: p of degree n is irreducible if and only if x^(2^n) = x mod p, and
+// x^(2^(n/r)) - x is coprime to p for every prime r dividing n. poly is p without its x^n term.
+func isIrreducible(degree int, poly uint64) bool {
+	if degree == 1 {
+		return true
+	}
+
+	// The arithmetic of Field works modulo any polynomial, not just irreducible ones.
+	ring := Field{degree: degree, poly: poly}
+
+	for _, r := range primeFactors(uint64(degree)) {
+		x := ring.frobenius(0x02, degree/int(r))
+		if gcdWithModulus(x^0x02, degree, poly) != 1 {
+			return false
+		}
+	}
+
+	return ring.frobenius(0x02, degree) == 0x02
+}
+
+// gcdWithModulus returns the greatest common divisor of a, a polynomial of degree less than n, and x^n + poly.
+func gcdWithModulus(a uint64, degree int, poly uint64) uint64 {
+	if a == 0 {
+		return 0 // The modulus doesn't fit in a uint64, but it's not one either.
+	}
+
+	da := bits.Len64(a) - 1
+	if da == 0 {
+		return 1
+	}
+
+	// Reduce x^n mod a one x at a time, so that nothing overflows.
+	xn := uint64(1)
+	for i := 0; i < degree; i++ {
+		if xn <<= 1; xn>>uint(da)&1 == 1 {
+			xn ^= a
+		}
+	}
+
+	b := xn ^ polyModBits(poly, a)
+	for b != 0 {
+		a, b = b, polyModBits(a, b)
+	}
+
+	return a
+}
+
+// polyModBits returns a mod b, where both are polynomials over GF(2) packed into bits.
+func polyModBits(a, b uint64) uint64 {
+	db := bits.Len64(b)
+	for da := bits.Len64(a); da >= db; da = bits.Len64(a) {
+		a ^= b << uint(da-db)
+	}
+
+	return a
+}
+
+// Degree returns the degree of the field over GF(2), so that it has 2^Degree() elements.
+func (f Field) Degree() int { return f.degree }
+
+// Poly returns the polynomial defining the field, without its x^n term.
+func (f Field) Poly() uint64 { return f.poly }
+
+// mask has the low n bits set. It's also 2^n - 1, the order of the multiplicative group.
+func (f Field) mask() uint64 { return ^uint64(0) >> uint(64-f.degree) }
+
+// Add returns a + b.
+func (f Field) Add(a, b uint64) uint64 {
+	return a ^ b
+}
+
+// Mul returns a * b.
+func (f Field) Mul(a, b uint64) (out uint64) {
+	a, b = a&f.mask(), b&f.mask()
+
+	for ; b != 0; b >>= 1 { // Foreach bit b_i in b, from the bottom up:
+		if b&1 == 1 {
+			out ^= a // Add a * x^i to the output.
+		}
+
+		carry := a >> uint(f.degree-1) // Multiply a by x mod M(x).
+		a = a << 1 & f.mask()
+		if carry == 1 {
+			a ^= f.poly
+		}
+	}
+
+	return
+}
+
+// Exp returns a^k, with 0^0 = 1.
+func (f Field) Exp(a, k uint64) uint64 {
+	out := uint64(1)
+
+	for ; k > 0; k >>= 1 {
+		if k&1 == 1 {
+			out = f.Mul(out, a)
+		}
+		a = f.Mul(a, a)
+	}
+
+	return out
+}
+
+// Invert returns the multiplicative inverse of a, or 0 if a = 0. It computes a^(2^n - 2).
+func (f Field) Invert(a uint64) uint64 {
+	if a&f.mask() == 0 {
+		return 0
+	}
+
+	return f.Exp(a, f.mask()-1)
+}
+
+// frobenius returns a^(2^k).
+func (f Field) frobenius(a uint64, k int) uint64 {
+	for i := 0; i < k; i++ {
+		a = f.Mul(a, a)
+	}
+
+	return a
+}
+
+// IsPrimitive returns true if a generates the multiplicative group of the field: that is, if a has order 2^n - 1.
+func (f Field) IsPrimitive(a uint64) bool {
+	if a&f.mask() == 0 {
+		return false
+	}
+
+	for _, r := range f.orderFactors {
+		if f.Exp(a, f.mask()/r) == 1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PrimitiveElement returns the smallest primitive element of the field.
+func (f Field) PrimitiveElement() uint64 {
+	for a := uint64(1); ; a++ {
+		if f.IsPrimitive(a) {
+			return a
+		}
+	}
+}
+
+// Isomorphism is an isomorphism between two binary fields of the same degree. Isomorphisms are linear over GF(2), so
+// it's stored as a binary matrix: entry i is the image of x^i, the element with only bit i set.
+type Isomorphism []uint64
+
+// Isomorphism returns an isomorphism from f to g. It sends x to a root of f's polynomial in g, and there are n of them,
+// so it's one of n possible isomorphisms. It panics if the fields are different sizes.
+func (f Field) Isomorphism(g Field) Isomorphism {
+	if f.degree != g.degree {
+		panic("Can't find isomorphism between fields of different sizes!")
+	}
+
+	// f's polynomial, as a polynomial over g.
+	p := make([]uint64, f.degree+1)
+	for i := 0; i < f.degree; i++ {
+		p[i] = f.poly >> uint(i) & 1
+	}
+	p[f.degree] = 1
+
+	root, out := g.root(p), make(Isomorphism, f.degree)
+	for i, power := 0, uint64(1); i < f.degree; i++ {
+		out[i], power = power, g.Mul(power, root)
+	}
+
+	return out
+}
+
+// Map returns the image of a under the isomorphism.
+func (iso Isomorphism) Map(a uint64) (out uint64) {
+	for i, col := range iso {
+		if a>>uint(i)&1 == 1 {
+			out ^= col
+		}
+	}
+
+	return
+}
+
+// Invert returns the inverse isomorphism.
+func (iso Isomorphism) Invert() Isomorphism {
+	cols, out := make(Isomorphism, len(iso)), make(Isomorphism, len(iso))
+	copy(cols, iso)
+	for i, _ := range out {
+		out[i] = 1 << uint(i)
+	}
+
+	// Column-reduce iso to the identity, keeping track of which inputs each column is the image of.
+	for j, _ := range cols {
+		pivot := -1
+		for k := j; k < len(cols); k++ {
+			if cols[k]>>uint(j)&1 == 1 {
+				pivot = k
+				break
+			}
+		}
+
+		if pivot == -1 {
+			panic("Can't invert isomorphism that isn't invertible!")
+		}
+
+		cols[j], cols[pivot] = cols[pivot], cols[j]
+		out[j], out[pivot] = out[pivot], out[j]
+
+		for k, _ := range cols {
+			if k != j && cols[k]>>uint(j)&1 == 1 {
+				cols[k] ^= cols[j]
+				out[k] ^= out[j]
+			}
+		}
+	}
+
+	return out
+}
+
+// root returns a root of a monic polynomial over f that splits into distinct linear factors, with entry i of h the
+// coefficient of y^i. It splits h with gcd(h, Tr(delta * y)) for different delta, keeping the smaller half, until only
+// a linear factor is left. This is the Cantor-Zassenhaus algorithm for characteristic 2.
+func (f Field) root(h []uint64) uint64 {
+	for len(h) > 2 {
+		split := false
+
+		// Tr(delta * (a + b)) is non-zero for some delta in any basis if a != b, so trying every x^j splits h.
+		for j := 0; j < f.degree && !split; j++ {
+			z := []uint64{0, 1 << uint(j)}
+			trace := z
+
+			for i := 1; i < f.degree; i++ {
+				z = poly.MulMod[uint64](f, z, z, h)
+				trace = poly.Add[uint64](f, trace, z)
+			}
+
+			a := poly.GCD[uint64](f, h, trace)
+			if len(a) <= 1 || len(a) == len(h) {
+				continue
+			}
+
+			if quo, _ := poly.DivMod[uint64](f, h, a); len(a) <= len(quo) {
+				h = a
+			} else {
+				h = quo
+			}
+			split = true
+		}
+
+		if !split {
+			panic("Can't find root of polynomial that doesn't split!")
+		}
+	}
+
+	// h = y + h_0, which has the root h_0 in characteristic 2.
+	return h[0]
+}
+
+// factorOrder returns the distinct prime factors of n in increasing order, using trial division for small factors and
+// Pollard's rho algorithm for the rest.
+func factorOrder(n uint64) []uint64 {
+	found := map[uint64]bool{}
+
+	var split func(n uint64)
+	split = func(n uint64) {
+		if n == 1 {
+			return
+		} else if new(big.Int).SetUint64(n).ProbablyPrime(0) { // Exact for n < 2^64.
+			found[n] = true
+			return
+		}
+
+		d := pollardRho(n)
+		split(d)
+		split(n / d)
+	}
+
+	for p := uint64(2); p < 1<<10 && p*p <= n; p++ {
+		for n%p == 0 {
+			found[p] = true
+			n /= p
+		}
+	}
+	split(n)
+
+	out := make([]uint64, 0, len(found))
+	for p, _ := range found {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+
+	return out
+}
+
+// primeFactors returns the distinct prime factors of a small n by trial division.
+func primeFactors(n uint64) (out []uint64) {
+	for p := uint64(2); p <= n; p++ {
+		if n%p == 0 {
+			out = append(out, p)
+			for n%p == 0 {
+				n /= p
+			}
+		}
+	}
+
+	return
+}
+
+// pollardRho returns a non-trivial factor of an odd composite n.
+func pollardRho(n uint64) uint64 {
+	mulMod := func(a, b uint64) uint64 {
+		hi, lo := bits.Mul64(a, b)
+		_, rem := bits.Div64(hi, lo, n)
+		return rem
+	}
+
+	for c := uint64(1); ; c++ {
+		step := func(x uint64) uint64 {
+			x, carry := bits.Add64(mulMod(x, x), c, 0)
+			if carry == 1 || x >= n {
+				x -= n
+			}
+			return x
+		}
+
+		x, y, d := uint64(2), uint64(2), uint64(1)
+		for d == 1 {
+			x, y = step(x), step(step(y))
+
+			diff := x - y
+			if x < y {
+				diff = y - x
+			}
+			for d = n; diff != 0; {
+				d, diff = diff, d%diff
+			}
+		}
+
+		if d != n {
+			return d
+		}
+	}
+}
@@ -5,48 +5,43 @@ package number
 // The two operations implemented are addition and multiplication. The additive identity is 0x00 and all elements have
 // themselves as additive inverses: x.Add(x) = 0x00 always. The multiplicative identity is 0x01 and all non-zero
 // elements have a multiplicative inverse such that x.Mul(x.Invert()) = 0x01.
+//
+// By default, multiplication, inversion, exponentiation and logarithms look their answers up in log/antilog tables.
+// That's fast, but which table entries are read depends on the operands, so it leaks them through cache timing.
+// Building with the constanttime tag (go build -tags constanttime) replaces the tables with bitwise arithmetic that has
+// no branches or memory accesses that depend on the operands, for side-channel-sensitive reference code. ConstantTime
+// reports which was built.
 type ByteFieldElem uint16
 
 var byteModulus ByteFieldElem = 0x11b
 
+// ByteGenerator is the base of ByteFieldElem's Exp and Log. It generates the multiplicative group of the field.
+const ByteGenerator ByteFieldElem = 0x03
+
 // Add returns e + f.
 func (e ByteFieldElem) Add(f ByteFieldElem) ByteFieldElem {
 	return e ^ f
 }
 
-// Mul returns e * f.
-func (e ByteFieldElem) Mul(f ByteFieldElem) (out ByteFieldElem) {
-	var i, j uint // uints because they are used for bit shifts
-
-	for i = 0; i < 8; i++ { // Foreach bit e_i in e:
-		if e&(1<<i) != 0 { // where e_i equals 1:
-			temp := f // Multiply f * x^i mod M(x):
-
-			for j = 0; j < i; j++ { // Multiply f by x mod M(x), i times.
-				temp = temp << 1
-
-				if temp >= 0x100 {
-					temp = temp ^ byteModulus
-				}
-			}
-
-			out = out ^ temp // Add f * x^i to the output
-		}
+// Exp returns e^k. Negative k are powers of e's inverse, with 0x00^k = 0x00 for all k other than zero.
+func (e ByteFieldElem) Exp(k int) ByteFieldElem {
+	if k == 0 {
+		return 0x01
+	} else if k < 0 {
+		e, k = e.Invert(), -k
 	}
 
-	return
+	return e.exp(k)
 }
 
-// Invert returns the multiplicative inverse of e, or 0x00 if e = 0x00.
-func (e ByteFieldElem) Invert() ByteFieldElem {
-	out, temp := e.Dup(), e.Dup()
-
-	for i := 0; i < 6; i++ {
-		temp = temp.Mul(temp)
-		out = out.Mul(temp)
+// Log returns the discrete logarithm of e to the base ByteGenerator: the k in [0, 255) such that
+// ByteGenerator.Exp(k) = e. It panics if e is zero.
+func (e ByteFieldElem) Log() int {
+	if e == 0 {
+		panic("Can't take logarithm of zero!")
 	}
 
-	return out.Mul(out)
+	return e.log()
 }
 
 // IsZero returns whether or not e is zero.
@@ -57,3 +52,21 @@ func (e ByteFieldElem) IsOne() bool { return e == 1 }
 
 // Dup returns a duplicate of e.
 func (e ByteFieldElem) Dup() ByteFieldElem { return e.Add(0) }
+
+// Modulus returns the polynomial defining Rijndael's field, x^8 + x^4 + x^3 + x + 1.
+func (e ByteFieldElem) Modulus() uint16 { return uint16(byteModulus) }
+
+// Degree returns the degree of Rijndael's field over GF(2), 8.
+func (e ByteFieldElem) Degree() int { return 8 }
+
+// mulConstantTime returns e * f, running through all eight bits of e regardless of their values.
+func mulConstantTime(e, f ByteFieldElem) (out ByteFieldElem) {
+	e, f = e&0xff, f&0xff
+
+	for i := 0; i < 8; i++ {
+		out ^= -(e >> uint(i) & 1) & f   // Add f * x^i to the output if e_i = 1.
+		f = f<<1 ^ -(f>>7&1)&byteModulus // Multiply f by x mod M(x).
+	}
+
+	return
+}
@@ -0,0 +1,52 @@
+//go:build constanttime
+
+package number
+
+// ConstantTime is true if ByteFieldElem was built with constant-time arithmetic, and false if it uses tables.
+const ConstantTime = true
+
+// Mul returns e * f.
+func (e ByteFieldElem) Mul(f ByteFieldElem) ByteFieldElem {
+	return mulConstantTime(e, f)
+}
+
+// Invert returns the multiplicative inverse of e, or 0x00 if e = 0x00. It computes e^254.
+func (e ByteFieldElem) Invert() ByteFieldElem {
+	out, temp := e.Dup(), e.Dup()
+
+	for i := 0; i < 6; i++ {
+		temp = temp.Mul(temp)
+		out = out.Mul(temp)
+	}
+
+	return out.Mul(out)
+}
+
+// exp returns e^k for k > 0 by square-and-multiply. Its running time depends on the bit length of k, but not on e.
+func (e ByteFieldElem) exp(k int) ByteFieldElem {
+	out := ByteFieldElem(0x01)
+
+	for ; k > 0; k >>= 1 {
+		mask := -ByteFieldElem(k & 1)
+		out = out.Mul(mask&e | ^mask&0x01)
+		e = e.Mul(e)
+	}
+
+	return out
+}
+
+// log returns the discrete logarithm of a non-zero e by computing every power of the generator and keeping the exponent
+// of the one equal to e.
+func (e ByteFieldElem) log() (out int) {
+	x := ByteFieldElem(0x01)
+
+	for k := 0; k < 255; k++ {
+		diff := int(x ^ e&0xff)
+		mask := (diff - 1) >> 8 // All ones if x = e, and zero otherwise.
+		out |= k & mask
+
+		x = x.Mul(ByteGenerator)
+	}
+
+	return
+}
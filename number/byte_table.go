@@ -0,0 +1,54 @@
+//go:build !constanttime
+
+package number
+
+// ConstantTime is true if ByteFieldElem was built with constant-time arithmetic, and false if it uses tables.
+const ConstantTime = false
+
+var (
+	expTable [510]ByteFieldElem // expTable[k] = 0x03^k, written out twice so sums of two logs don't need reducing.
+	logTable [256]int           // logTable[x] = k such that 0x03^k = x. logTable[0] is unused.
+)
+
+func init() {
+	x := ByteFieldElem(0x01)
+
+	for k := 0; k < 255; k++ {
+		expTable[k], expTable[k+255] = x, x
+		logTable[x] = k
+
+		x = mulConstantTime(x, ByteGenerator)
+	}
+}
+
+// Mul returns e * f.
+func (e ByteFieldElem) Mul(f ByteFieldElem) ByteFieldElem {
+	if e == 0 || f == 0 {
+		return 0
+	}
+
+	return expTable[logTable[byte(e)]+logTable[byte(f)]]
+}
+
+// Invert returns the multiplicative inverse of e, or 0x00 if e = 0x00.
+func (e ByteFieldElem) Invert() ByteFieldElem {
+	if e == 0 {
+		return 0
+	}
+
+	return expTable[255-logTable[byte(e)]]
+}
+
+// exp returns e^k for k > 0.
+func (e ByteFieldElem) exp(k int) ByteFieldElem {
+	if e == 0 {
+		return 0
+	}
+
+	return expTable[logTable[byte(e)]*(k%255)%255]
+}
+
+// log returns the discrete logarithm of a non-zero e.
+func (e ByteFieldElem) log() int {
+	return logTable[byte(e)]
+}
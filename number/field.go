@@ -0,0 +1,97 @@
+package number
+
+import (
+	"math/bits"
+)
+
+// Modulus names an irreducible polynomial over GF(2) of degree at most 8, which defines a binary field for FieldElem.
+// Implementations are empty structs, so that the field is part of the element's type:
+//
+//	type MyModulus struct{}
+//
+//	func (MyModulus) Poly() uint16 { return 0x11d }
+type Modulus interface {
+	// Poly returns the polynomial, with bit i set if x^i has a non-zero coefficient.
+	Poly() uint16
+}
+
+// AES is the modulus of Rijndael's field, x^8 + x^4 + x^3 + x + 1. FieldElem[AES] is the same field as ByteFieldElem.
+type AES struct{}
+
+func (AES) Poly() uint16 { return 0x11b }
+
+// SM4 is the modulus of the field SM4's S-box is built on, x^8 + x^7 + x^6 + x^5 + x^4 + x^2 + 1.
+type SM4 struct{}
+
+func (SM4) Poly() uint16 { return 0x1f5 }
+
+// Kuznyechik is the modulus of the field Kuznyechik's (and Streebog's) linear layer is defined over,
+// x^8 + x^7 + x^6 + x + 1.
+type Kuznyechik struct{}
+
+func (Kuznyechik) Poly() uint16 { return 0x1c3 }
+
+// Nibble is the modulus of the usual field on nibbles, GF(2^4) with x^4 + x + 1.
+type Nibble struct{}
+
+func (Nibble) Poly() uint16 { return 0x13 }
+
+// FieldElem is an element of the binary field GF(2^n) defined by the modulus M, where n <= 8 is the degree of M. It's
+// stored in the low n bits of a uint16, like ByteFieldElem.
+type FieldElem[M Modulus] uint16
+
+// Add returns e + f.
+func (e FieldElem[M]) Add(f FieldElem[M]) FieldElem[M] {
+	return e ^ f
+}
+
+// Mul returns e * f.
+func (e FieldElem[M]) Mul(f FieldElem[M]) (out FieldElem[M]) {
+	mod := e.Modulus()
+	top := FieldElem[M](1) << uint(bits.Len16(mod)-1)
+
+	for ; e != 0; e >>= 1 { // Foreach bit e_i in e, from the bottom up:
+		if e&1 == 1 {
+			out ^= f // Add f * x^i to the output.
+		}
+
+		f <<= 1 // Multiply f by x mod M(x).
+		if f&top != 0 {
+			f ^= FieldElem[M](mod)
+		}
+	}
+
+	return
+}
+
+// Invert returns the multiplicative inverse of e, or 0 if e = 0. It computes e^(2^n - 2).
+func (e FieldElem[M]) Invert() FieldElem[M] {
+	out, temp := FieldElem[M](1), e.Mul(e)
+
+	for i := 1; i < e.Degree(); i++ {
+		out = out.Mul(temp)
+		temp = temp.Mul(temp)
+	}
+
+	return out
+}
+
+// IsZero returns whether or not e is zero.
+func (e FieldElem[M]) IsZero() bool { return e == 0 }
+
+// IsOne returns whether or not e is one.
+func (e FieldElem[M]) IsOne() bool { return e == 1 }
+
+// Dup returns a duplicate of e.
+func (e FieldElem[M]) Dup() FieldElem[M] { return e }
+
+// Modulus returns the polynomial defining e's field.
+func (e FieldElem[M]) Modulus() uint16 {
+	var m M
+	return m.Poly()
+}
+
+// Degree returns the degree of e's field over GF(2), so that it has 2^Degree() elements.
+func (e FieldElem[M]) Degree() int {
+	return bits.Len16(e.Modulus()) - 1
+}
@@ -1,3 +1,4 @@
 // Package number implements Rijndael's field (an 8th degree extension of F_2), and Rijndael's ring (a 4th degree ring
-// extension of the field).
+// extension of the field). It also implements arbitrary binary fields of degree up to 64, and the isomorphisms between
+// different representations of the same field.
 package number
@@ -23,6 +23,38 @@ func TestByteFieldElemInvert(t *testing.T) {
 	}
 }
 
+// TestByteFieldElemArithmetic checks whichever implementation was built against bitwise multiplication. Run it with
+// and without -tags constanttime to check both.
+func TestByteFieldElemArithmetic(t *testing.T) {
+	for x := 0; x < 256; x++ {
+		for y := 0; y < 256; y++ {
+			if ByteFieldElem(x).Mul(ByteFieldElem(y)) != mulConstantTime(ByteFieldElem(x), ByteFieldElem(y)) {
+				t.Fatalf("%x * %x is wrong.", x, y)
+			}
+		}
+	}
+
+	if ByteFieldElem(0x57).Mul(0x83) != 0xc1 || ByteFieldElem(0x00).Invert() != 0x00 {
+		t.Fatal("Arithmetic is wrong.")
+	}
+
+	for w := 1; w < 256; w++ {
+		x := ByteFieldElem(w)
+
+		if x.Mul(x.Invert()) != 0x01 {
+			t.Fatalf("%x * %x^-1 != 1.", x, x)
+		} else if k := x.Log(); ByteGenerator.Exp(k) != x || k < 0 || k >= 255 {
+			t.Fatalf("Log of %x was %v.", x, k)
+		} else if x.Exp(3) != x.Mul(x).Mul(x) || x.Exp(-1) != x.Invert() || x.Exp(255) != 0x01 {
+			t.Fatalf("Exp of %x is wrong.", x)
+		}
+	}
+
+	if ByteFieldElem(0).Exp(0) != 0x01 || ByteFieldElem(0).Exp(5) != 0x00 {
+		t.Fatal("Exp of zero is wrong.")
+	}
+}
+
 func TestFactor(t *testing.T) {
 	for w := 1; w < 256; w++ {
 		x := ByteFieldElem(w)
@@ -77,3 +109,153 @@ func TestArrayRingElemMultiplicationInvert(t *testing.T) {
 		t.Fatal("Invert is wrong, found inverse of non-unit.")
 	}
 }
+
+func TestFieldElemAES(t *testing.T) {
+	for x := 0; x < 256; x++ {
+		for y := 0; y < 256; y++ {
+			if uint16(FieldElem[AES](x).Mul(FieldElem[AES](y))) != uint16(ByteFieldElem(x).Mul(ByteFieldElem(y))) {
+				t.Fatalf("FieldElem[AES] and ByteFieldElem disagree on %x * %x!", x, y)
+			}
+		}
+	}
+}
+
+func TestFieldElemInvert(t *testing.T) {
+	testFieldElemInvert[SM4](t)
+	testFieldElemInvert[Kuznyechik](t)
+	testFieldElemInvert[Nibble](t)
+}
+
+func testFieldElemInvert[M Modulus](t *testing.T) {
+	size := 1 << uint(FieldElem[M](0).Degree())
+
+	for w := 1; w < size; w++ {
+		x := FieldElem[M](w)
+		y := x.Invert()
+
+		if int(x.Mul(y)) >= size || !x.Mul(y).IsOne() {
+			t.Fatalf("Multiplication of %T by inverse did not equal one!", x)
+		}
+	}
+}
+
+// benchmarkByteFieldElem benchmarks whichever implementation was built. Compare the two with
+//
+//	go test -bench ByteFieldElem ./number/
+//	go test -tags constanttime -bench ByteFieldElem ./number/
+func benchmarkByteFieldElem(b *testing.B, f func(x, y ByteFieldElem) ByteFieldElem) {
+	x := ByteFieldElem(0x57)
+	for i := 0; i < b.N; i++ {
+		x = f(x, ByteFieldElem(i&0xff|1))
+	}
+}
+
+func BenchmarkByteFieldElemMul(b *testing.B) {
+	benchmarkByteFieldElem(b, func(x, y ByteFieldElem) ByteFieldElem { return x.Mul(y) })
+}
+
+func BenchmarkByteFieldElemInvert(b *testing.B) {
+	benchmarkByteFieldElem(b, func(x, y ByteFieldElem) ByteFieldElem { return x.Add(y).Invert() })
+}
+
+func BenchmarkByteFieldElemExp(b *testing.B) {
+	benchmarkByteFieldElem(b, func(x, y ByteFieldElem) ByteFieldElem { return x.Exp(int(y)) })
+}
+
+func BenchmarkByteFieldElemLog(b *testing.B) {
+	benchmarkByteFieldElem(b, func(x, y ByteFieldElem) ByteFieldElem { return x ^ ByteFieldElem(y.Log()) })
+}
+
+func TestFieldMatchesByteFieldElem(t *testing.T) {
+	f := NewFieldOf[AES]()
+
+	for x := 0; x < 256; x++ {
+		for y := 0; y < 256; y++ {
+			if f.Mul(uint64(x), uint64(y)) != uint64(ByteFieldElem(x).Mul(ByteFieldElem(y))) {
+				t.Fatalf("Field and ByteFieldElem disagree on %x * %x.", x, y)
+			}
+		}
+
+		if f.Invert(uint64(x)) != uint64(ByteFieldElem(x).Invert()) {
+			t.Fatalf("Field and ByteFieldElem disagree on %x^-1.", x)
+		}
+	}
+}
+
+func TestIsIrreducible(t *testing.T) {
+	cases := []struct {
+		degree int
+		poly   uint64
+		real   bool
+	}{
+		{1, 0x2, true},
+		{2, 0x7, true},
+		{4, 0x13, true},
+		{4, 0x15, false}, // (x^2 + x + 1)^2
+		{4, 0x1f, true},
+		{8, 0x11b, true},
+		{8, 0x1f5, true},
+		{8, 0x11d, true},
+		{8, 0x101, false},
+		{64, 0x1b, true},
+		{64, 0x1f, false}, // Has x + 1 as a factor, since it has an even number of terms.
+	}
+
+	for _, c := range cases {
+		if IsIrreducible(c.degree, c.poly) != c.real {
+			t.Fatalf("IsIrreducible(%v, %x) != %v", c.degree, c.poly, c.real)
+		}
+	}
+}
+
+func TestFieldPrimitive(t *testing.T) {
+	aes := NewFieldOf[AES]()
+	if aes.IsPrimitive(0x02) || !aes.IsPrimitive(0x03) || aes.PrimitiveElement() != 0x03 {
+		t.Fatal("Primitive elements of Rijndael's field are wrong.")
+	}
+
+	// x^4 + x^3 + x^2 + x + 1 divides x^5 + 1, so x has order 5.
+	if f := NewField(4, 0x1f); f.IsPrimitive(0x02) || !f.IsPrimitive(0x03) {
+		t.Fatal("Primitive elements of GF(2^4) are wrong.")
+	}
+
+	f := NewField(64, 0x1b)
+	g := f.PrimitiveElement()
+	if !f.IsPrimitive(g) || f.Exp(g, ^uint64(0)) != 1 || f.Mul(g, f.Invert(g)) != 1 {
+		t.Fatal("Primitive element of GF(2^64) is wrong.")
+	}
+}
+
+func TestFieldIsomorphism(t *testing.T) {
+	sm4, aes := NewFieldOf[SM4](), NewFieldOf[AES]()
+	iso := sm4.Isomorphism(aes)
+	inv := iso.Invert()
+
+	// Inversion in SM4's field is inversion in Rijndael's field, conjugated by the isomorphism.
+	for x := uint64(0); x < 256; x++ {
+		if iso.Map(sm4.Invert(x)) != aes.Invert(iso.Map(x)) {
+			t.Fatalf("Isomorphism doesn't commute with inversion at %x.", x)
+		} else if inv.Map(iso.Map(x)) != x {
+			t.Fatalf("Inverse isomorphism doesn't undo isomorphism at %x.", x)
+		}
+
+		for y := uint64(0); y < 256; y++ {
+			if iso.Map(sm4.Mul(x, y)) != aes.Mul(iso.Map(x), iso.Map(y)) {
+				t.Fatalf("Isomorphism doesn't commute with multiplication at %x * %x.", x, y)
+			}
+		}
+	}
+
+	// x^64 + x^63 + x^61 + x^60 + 1 is the reciprocal of x^64 + x^4 + x^3 + x + 1, so it's irreducible too.
+	f, g := NewField(64, 0x1b), NewField(64, 1<<63|1<<61|1<<60|1)
+	iso = f.Isomorphism(g)
+
+	for _, x := range []uint64{0x02, 0x0123456789abcdef, 0xfedcba9876543210} {
+		y := uint64(0xdeadbeefcafebabe)
+		if iso.Map(f.Mul(x, y)) != g.Mul(iso.Map(x), iso.Map(y)) {
+			t.Fatalf("Isomorphism of GF(2^64) doesn't commute with multiplication at %x.", x)
+		} else if iso.Invert().Map(iso.Map(x)) != x {
+			t.Fatalf("Inverse isomorphism of GF(2^64) doesn't undo isomorphism at %x.", x)
+		}
+	}
+}
@@ -29,3 +29,14 @@ func TestStream(t *testing.T) {
 		t.Fatalf("Two streams with different seed gave same output!")
 	}
 }
+
+func TestMatrix(t *testing.T) {
+	source1 := NewSource("Random Tests", make([]byte, 16))
+	source2 := NewSource("Random Tests", make([]byte, 16))
+
+	label := make([]byte, 16)
+
+	if !source1.Matrix(label, 128).Equals(source2.Matrix(label, 128)) {
+		t.Fatalf("Two sources with same seed didn't give same matrix!")
+	}
+}